@@ -92,6 +92,11 @@ func (a *Animator) Update(position basics.Vector2f, deltaTime float64) {
 	a.previousFrame = a.currentFrame
 }
 
+// SetPosition moves where the animation is drawn without advancing it
+func (a *Animator) SetPosition(position basics.Vector2f) {
+	a.position = position
+}
+
 func (a *Animator) Draw(screen *ebiten.Image) {
 	options := &ebiten.DrawImageOptions{}
 
@@ -0,0 +1,50 @@
+package catalog
+
+// ConsumableRecipe describes a consumable the workbench can make. Each
+// craft adds one charge, used from the hotbar while diving, and the
+// cooldown is how long a dive has to wait before the next use.
+type ConsumableRecipe struct {
+	Name         string
+	Description  string
+	Cooldown     float64
+	Materials    map[string]float64
+	IconFilepath string
+}
+
+const (
+	MagneticPulse = "MAGNETIC PULSE"
+	SonarPing     = "SONAR PING"
+	TimeExtender  = "TIME EXTENDER"
+)
+
+const (
+	// a pulse grows the magnetic field and its pull for a moment
+	PulseDuration        = 1.5
+	PulseFieldMultiplier = 3
+	PulseAttraction      = 4
+	// sonar lights up junk this rare or rarer for a while
+	SonarDuration    = 5
+	SonarMaxRarity   = 10
+	TimeExtenderTime = 10
+)
+
+var ConsumableRecipes = []ConsumableRecipe{
+	{MagneticPulse, "Pulls in all junk in range", 8, map[string]float64{"Iron": 30, "Copper": 20}, "images/iconpulse.png"},
+	{SonarPing, "Reveals rare junk", 10, map[string]float64{"Copper": 20, "Plastic": 20}, "images/iconsonar.png"},
+	{TimeExtender, "Adds 10 seconds to the dive", 15, map[string]float64{"Steel": 20, "Nickel": 10}, "images/icontimeextender.png"},
+}
+
+// GetConsumable returns the recipe of a consumable, false if there isn't one
+func GetConsumable(name string) (ConsumableRecipe, bool) {
+	for _, v := range ConsumableRecipes {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return ConsumableRecipe{}, false
+}
+
+// CanAfford reports whether the materials cover a charge of the consumable
+func (c ConsumableRecipe) CanAfford(materials map[string]int) bool {
+	return CanAfford(c.Materials, materials)
+}
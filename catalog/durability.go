@@ -0,0 +1,67 @@
+package catalog
+
+import "math"
+
+// WearKind is something that wears down the key items in a slot
+type WearKind int
+
+const (
+	CastWear WearKind = iota
+	CatchWear
+	// strain wears per second the line spends off the reel sweet spot
+	StrainWear
+)
+
+// Wear is how much durability a key item loses to each kind of wear
+type Wear struct {
+	Cast, Catch, Strain float64
+}
+
+const (
+	MaxDurability = 100
+	// below this a key item starts losing its modifiers, down to
+	// BrokenModifierShare of them once it has no durability left
+	WornDurability      = 30
+	BrokenModifierShare = 0.5
+	// repairing a key item with no durability left costs this share of the
+	// recipe, less worn ones cost less
+	repairCostShare = 0.3
+)
+
+func (w Wear) Get(kind WearKind) float64 {
+	switch kind {
+	case CastWear:
+		return w.Cast
+	case CatchWear:
+		return w.Catch
+	case StrainWear:
+		return w.Strain
+	}
+	return 0
+}
+
+// Wears is true for slots whose key items lose durability at all
+func (s SlotDefinition) Wears() bool {
+	return s.Wear != Wear{}
+}
+
+// DurabilityShare is how much of its modifiers a key item with durability
+// still gives
+func DurabilityShare(durability float64) float64 {
+	if durability >= WornDurability {
+		return 1
+	}
+	return BrokenModifierShare + (1-BrokenModifierShare)*math.Max(durability, 0)/WornDurability
+}
+
+// RepairCost is what bringing a key item with recipe back to full
+// durability costs
+func RepairCost(recipe map[string]float64, durability float64) map[string]float64 {
+	scale := repairCostShare * (MaxDurability - durability) / MaxDurability
+
+	cost := make(map[string]float64)
+	for k, v := range recipe {
+		cost[k] = math.Ceil(v * scale)
+	}
+	return cost
+}
@@ -0,0 +1,228 @@
+package catalog
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/mharv/scrapyard-charter/loot"
+)
+
+// casts shorter than this are weighted as if they reached it
+const minCastPercent = 1
+
+// junk at the bottom of the pit spawns buried this share of the time, less
+// the higher up it is
+const MaxBuriedChance = 0.5
+
+// MaterialRange is how much of a material salvaging a piece of junk gives,
+// rolled in [Min, Max)
+type MaterialRange struct {
+	Name     string
+	Min, Max int
+}
+
+// Byproduct is a material salvaging can turn up on top of the junk's own
+// materials, Chance of the time. Some only come out with a precision tool.
+type Byproduct struct {
+	Name          string
+	Min, Max      int
+	Chance        float64
+	NeedPrecision bool
+}
+
+// Junk describes a type of junk that can spawn in the scavenge pit. The
+// scavenge scene builds its junk objects from these and the balance tool
+// simulates against them, so changes here show up in both.
+type Junk struct {
+	Name           string
+	ImageFilepath  string
+	Depth          float64
+	Rarity         float64
+	RarityScale    float64
+	ReelDifficulty float64
+	TimeBonus      float64
+	Materials      []MaterialRange
+	Byproducts     []Byproduct
+	AudioFiles     []string
+}
+
+var JunkList = []Junk{
+	{
+		Name:          "Cog",
+		ImageFilepath: "images/cog.png",
+		Depth:         0,
+		Rarity:        80,
+		RarityScale:   0.1,
+		Materials:     []MaterialRange{{"Iron", 5, 10}},
+		AudioFiles:    []string{"audio/mom1.mp3", "audio/mom2.mp3", "audio/mom3.mp3", "audio/mom4.mp3", "audio/mom12.mp3"},
+	},
+	{
+		Name:          "Iron Pipe",
+		ImageFilepath: "images/ironpipe.png",
+		Depth:         1,
+		Rarity:        60,
+		RarityScale:   0.2,
+		Materials:     []MaterialRange{{"Iron", 15, 30}},
+		AudioFiles:    []string{"audio/mop1.mp3", "audio/mop2.mp3"},
+	},
+	{
+		Name:          "Tyre",
+		ImageFilepath: "images/tyre.png",
+		Depth:         2,
+		Rarity:        50,
+		RarityScale:   0.2,
+		Materials:     []MaterialRange{{"Rubber", 15, 25}, {"Iron", 15, 20}},
+		AudioFiles:    []string{"audio/mor1.mp3", "audio/mor2.mp3", "audio/mor3.mp3", "audio/mor4.mp3"},
+	},
+	{
+		Name:          "Steel Bike Frame",
+		ImageFilepath: "images/steelbikeframe.png",
+		Depth:         3,
+		Rarity:        40,
+		RarityScale:   0.3,
+		Materials:     []MaterialRange{{"Steel", 15, 20}, {"Iron", 2, 7}, {"Plastic", 0, 1}},
+		AudioFiles:    []string{"audio/mom5.mp3", "audio/mom6.mp3", "audio/mom7.mp3", "audio/mom8.mp3", "audio/mom9.mp3"},
+	},
+	{
+		Name:          "Monitor",
+		ImageFilepath: "images/monitor.png",
+		Depth:         4,
+		Rarity:        30,
+		RarityScale:   0.4,
+		Materials:     []MaterialRange{{"Copper", 10, 15}, {"Plastic", 5, 10}, {"Iron", 0, 2}},
+		AudioFiles:    []string{"audio/mor5.mp3", "audio/mor6.mp3"},
+	},
+	{
+		Name:          "Toaster",
+		ImageFilepath: "images/toaster.png",
+		Depth:         5,
+		Rarity:        20,
+		RarityScale:   0.5,
+		Materials:     []MaterialRange{{"Nickel", 2, 10}, {"Iron", 1, 5}},
+		AudioFiles:    []string{"audio/mom8.mp3", "audio/mom9.mp3", "audio/mom10.mp3", "audio/mom11.mp3"},
+	},
+	{
+		Name:          "Steel Pipe",
+		ImageFilepath: "images/steelpipe.png",
+		Depth:         6,
+		Rarity:        18,
+		RarityScale:   0.6,
+		Materials:     []MaterialRange{{"Steel", 15, 30}},
+		AudioFiles:    []string{"audio/mop3.mp3", "audio/mop4.mp3"},
+	},
+	{
+		Name:          "Belt",
+		ImageFilepath: "images/belt.png",
+		Depth:         7,
+		Rarity:        15,
+		RarityScale:   0.7,
+		Materials:     []MaterialRange{{"Cobalt", 1, 7}, {"Rubber", 3, 8}, {"Plastic", 0, 2}},
+		AudioFiles:    []string{"audio/belt1.mp3", "audio/belt1.mp3"},
+	},
+	{
+		Name:          "Copper Pipe",
+		ImageFilepath: "images/copperpipe.png",
+		Depth:         8,
+		Rarity:        13,
+		RarityScale:   0.9,
+		Materials:     []MaterialRange{{"Copper", 15, 30}},
+		AudioFiles:    []string{"audio/mop5.mp3", "audio/mop6.mp3"},
+	},
+	{
+		Name:           "Titanium Bike Frame",
+		ImageFilepath:  "images/titaniumbikeframe.png",
+		Depth:          9,
+		Rarity:         10,
+		RarityScale:    1.2,
+		ReelDifficulty: 0.4,
+		Materials:      []MaterialRange{{"Titanium", 15, 25}, {"Iron", 0, 2}, {"Plastic", 2, 4}},
+		AudioFiles:     []string{"audio/mom9.mp3", "audio/mom10.mp3"},
+	},
+	{
+		Name:           "Titanium Pipe",
+		ImageFilepath:  "images/titaniumpipe.png",
+		Depth:          10,
+		Rarity:         9,
+		RarityScale:    1.4,
+		ReelDifficulty: 0.4,
+		Materials:      []MaterialRange{{"Titanium", 15, 30}},
+		AudioFiles:     []string{"audio/mop6.mp3", "audio/mop7.mp3"},
+	},
+	{
+		Name:           "Old PC",
+		ImageFilepath:  "images/oldpc.png",
+		Depth:          11,
+		Rarity:         8,
+		RarityScale:    1.8,
+		ReelDifficulty: 0.6,
+		Materials:      []MaterialRange{{"Copper", 10, 15}, {"Plastic", 8, 12}, {"Steel", 3, 7}},
+		Byproducts:     []Byproduct{{"Gold", 2, 5, 0.6, true}},
+		AudioFiles:     []string{"audio/mom7.mp3", "audio/mom8.mp3"},
+	},
+	{
+		Name:           "Battery",
+		ImageFilepath:  "images/battery.png",
+		Depth:          12,
+		Rarity:         4,
+		RarityScale:    2.5,
+		ReelDifficulty: 0.8,
+		Materials:      []MaterialRange{{"Cobalt", 10, 15}, {"Nickel", 10, 15}},
+		Byproducts:     []Byproduct{{"Copper", 3, 8, 0.5, false}},
+		AudioFiles:     []string{"audio/belt1.mp3", "audio/belt1.mp3"},
+	},
+	{
+		Name:          "Stopwatch",
+		ImageFilepath: "images/stopwatch.png",
+		Depth:         6,
+		Rarity:        10,
+		RarityScale:   0.4,
+		TimeBonus:     5,
+		AudioFiles:    []string{"audio/mom1.mp3", "audio/mom2.mp3"},
+	},
+}
+
+// Weight is how likely the junk is to spawn relative to the rest of the
+// list, castPercent being how far the overworld cast went out of the
+// players maximum, 0 to 100
+func (j Junk) Weight(castPercent float64) float64 {
+	return j.Rarity * (castPercent * j.RarityScale)
+}
+
+// JunkTable weights every junk in list for a cast, the index of each
+// entry in the table matches its index in list. A cast that goes nowhere
+// still lands in the pit, so it is weighted as the shortest cast rather
+// than one that can find nothing.
+func JunkTable(list []Junk, castPercent float64) loot.Table {
+	castPercent = math.Max(castPercent, minCastPercent)
+
+	table := loot.Table{}
+	table.Init()
+	for _, v := range list {
+		table.Add(v.Weight(castPercent))
+	}
+	return table
+}
+
+// PickJunk returns the index into list of a junk chosen by weight
+func PickJunk(rnd *rand.Rand, list []Junk, castPercent float64) int {
+	table := JunkTable(list, castPercent)
+
+	chosen, err := table.Pick(rnd)
+	if err != nil {
+		fmt.Println(err)
+		return 0
+	}
+	return chosen
+}
+
+// BuriedChance is how likely junk spawning at depthPercent of the way down
+// the pit, 0 to 1, is to be buried
+func BuriedChance(depthPercent float64) float64 {
+	return MaxBuriedChance * math.Max(math.Min(depthPercent, 1), 0)
+}
+
+// RollAmount picks a material amount in [min, max)
+func RollAmount(rnd *rand.Rand, min, max int) int {
+	return rnd.Intn(max-min) + min
+}
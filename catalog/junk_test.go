@@ -0,0 +1,25 @@
+package catalog
+
+import "testing"
+
+func TestBuriedChance(t *testing.T) {
+	tests := []struct {
+		name         string
+		depthPercent float64
+		want         float64
+	}{
+		{"top", 0, 0},
+		{"halfway", 0.5, MaxBuriedChance / 2},
+		{"bottom", 1, MaxBuriedChance},
+		{"above the top", -0.5, 0},
+		{"below the bottom", 1.5, MaxBuriedChance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuriedChance(tt.depthPercent); got != tt.want {
+				t.Errorf("BuriedChance(%v) = %v, want %v", tt.depthPercent, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package catalog
+
+import "math"
+
+// KeyItemRecipe describes a key item the crafting bench can make
+type KeyItemRecipe struct {
+	Name         string
+	Type         Slot
+	Modifiers    []Modifier
+	Materials    map[string]float64
+	IconFilepath string
+}
+
+// Modifier is a stat a key item changes, by Value at level 1 and PerLevel
+// more for each level after that. Modifiers that don't grow can't be
+// upgraded.
+type Modifier struct {
+	Name     string
+	Value    float64
+	PerLevel float64
+}
+
+// Modifier names are the names of the player stats they add to
+const (
+	MagnetFieldSizeModifier = "Magnet field size"
+	AttractionModifier      = "Attraction"
+	OverworldSpeedModifier  = "Overworld Speed"
+	DiveSpeedModifier       = "Dive Speed"
+	CastSpeedModifier       = "Cast Speed"
+	ReelSpeedModifier       = "Reel Speed"
+	LineLengthModifier      = "Line Length"
+	CastDistanceModifier    = "Cast Distance"
+	DiveTimeModifier        = "Dive Time"
+	CarryWeightModifier     = "Carry Weight"
+	SalvageYieldModifier    = "Salvage Yield"
+	PrecisionModifier       = "Precision"
+	ElectroMagnetModifier   = "Electro Magnet"
+	RepulsorModifier        = "Repulsor"
+	DetectionRangeModifier  = "Detection Range"
+)
+
+const (
+	// crafting the winning key item completes the game
+	WinningKeyItem = "GOLDENMAGNET"
+	// with this much gold the bench always makes the winning key item
+	WinningGold = 50
+
+	MaxKeyItemLevel = 5
+	// going from level 1 to 2 costs this share of the recipe, and each
+	// level after that costs upgradeCostGrowth more
+	upgradeCostBase   = 0.5
+	upgradeCostGrowth = 0.25
+)
+
+var KeyItemRecipes = []KeyItemRecipe{
+	// MAGNETS
+	{"THE CLASSIC", MagnetSlot, []Modifier{{MagnetFieldSizeModifier, 50, 10}, {AttractionModifier, 0.5, 0.1}}, map[string]float64{"Iron": 50, "Nickel": 25, "Cobalt": 25}, "images/iconmagnet1.png"},
+	{"BABY BOY BLUE", MagnetSlot, []Modifier{{MagnetFieldSizeModifier, 100, 15}, {AttractionModifier, 1, 0.15}}, map[string]float64{"Steel": 75, "Nickel": 40, "Cobalt": 40}, "images/iconmagnet2.png"},
+	{"TITAN", MagnetSlot, []Modifier{{MagnetFieldSizeModifier, 200, 25}, {AttractionModifier, 1.5, 0.2}}, map[string]float64{"Steel": 100, "Titanium": 75, "Nickel": 40, "Cobalt": 40}, "images/iconmagnet3.png"},
+	{WinningKeyItem, MagnetSlot, []Modifier{{MagnetFieldSizeModifier, 999, 0}, {AttractionModifier, 3, 0}}, map[string]float64{"Gold": WinningGold}, "images/iconmagnetgold.png"},
+
+	// DAS BOOTS
+	{"GUM BOOTS", BootsSlot, []Modifier{{OverworldSpeedModifier, 100, 20}, {DiveSpeedModifier, 100, 20}}, map[string]float64{"Rubber": 100, "Iron": 100, "Plastic": 20}, "images/iconboots1.png"},
+	{"TIM'S", BootsSlot, []Modifier{{OverworldSpeedModifier, 200, 30}, {DiveSpeedModifier, 200, 30}}, map[string]float64{"Rubber": 200, "Iron": 150, "Plastic": 40}, "images/iconboots2.png"},
+	{"CUTE REDS", BootsSlot, []Modifier{{OverworldSpeedModifier, 300, 40}, {DiveSpeedModifier, 300, 40}}, map[string]float64{"Rubber": 300, "Iron": 200, "Plastic": 100}, "images/iconboots3.png"},
+
+	// RODS
+	{"RODGER", RodSlot, []Modifier{{CastSpeedModifier, 200, 30}}, map[string]float64{"Rubber": 100, "Iron": 50, "Plastic": 20}, "images/iconrod1.png"},
+	{"RED ROCKET", RodSlot, []Modifier{{CastSpeedModifier, 400, 50}}, map[string]float64{"Rubber": 200, "Steel": 100, "Plastic": 40}, "images/iconrod2.png"},
+	{"PURPLE WHIP", RodSlot, []Modifier{{CastSpeedModifier, 600, 70}}, map[string]float64{"Rubber": 300, "Titanium": 100, "Plastic": 60}, "images/iconrod3.png"},
+
+	// REELS
+	{"REELY", ReelSlot, []Modifier{{ReelSpeedModifier, 100, 20}}, map[string]float64{"Iron": 300}, "images/iconreel1.png"},
+	{"WHITE WONDER", ReelSlot, []Modifier{{ReelSpeedModifier, 200, 30}}, map[string]float64{"Steel": 300}, "images/iconreel2.png"},
+	{"REALTY", ReelSlot, []Modifier{{ReelSpeedModifier, 300, 40}}, map[string]float64{"Titanium": 300}, "images/iconreel3.png"},
+
+	// LINES
+	{"LINE 'EM UP", LineSlot, []Modifier{{LineLengthModifier, 150, 20}, {CastDistanceModifier, 50, 20}}, map[string]float64{"Rubber": 200}, "images/iconline1.png"},
+	{"FAIRY FLOSS", LineSlot, []Modifier{{LineLengthModifier, 300, 30}, {CastDistanceModifier, 200, 30}}, map[string]float64{"Steel": 200}, "images/iconline2.png"},
+	{"LINE DANCER", LineSlot, []Modifier{{LineLengthModifier, 450, 40}, {CastDistanceModifier, 350, 40}}, map[string]float64{"Copper": 200}, "images/iconline3.png"},
+
+	// ELECTROMAGNET
+	{"ELECTRIFY", ElectromagnetSlot, []Modifier{{"Hold down 'Space'", 1337, 0}}, map[string]float64{"Copper": 100}, "images/iconelectromagnet.png"},
+
+	// REPULSOR
+	{"THE FUTURE", RepulsorSlot, []Modifier{{"Use with 'Tab'", 420, 0}}, map[string]float64{"Nickel": 30, "Cobalt": 30}, "images/iconrepulsor.png"},
+
+	// TANKS
+	{"AIR HEAD", TankSlot, []Modifier{{DiveTimeModifier, 10, 2}}, map[string]float64{"Iron": 100, "Rubber": 50}, "images/icontank1.png"},
+	{"DEEP BREATH", TankSlot, []Modifier{{DiveTimeModifier, 20, 3}}, map[string]float64{"Steel": 150, "Rubber": 100}, "images/icontank2.png"},
+	{"LUNG BUSTER", TankSlot, []Modifier{{DiveTimeModifier, 30, 4}}, map[string]float64{"Titanium": 150, "Rubber": 150}, "images/icontank3.png"},
+
+	// BACKPACKS
+	{"SCHOOL BAG", BackpackSlot, []Modifier{{CarryWeightModifier, 250, 50}}, map[string]float64{"Rubber": 80, "Plastic": 40}, "images/iconbackpack1.png"},
+	{"HIKING PACK", BackpackSlot, []Modifier{{CarryWeightModifier, 500, 80}}, map[string]float64{"Rubber": 150, "Steel": 80, "Plastic": 40}, "images/iconbackpack2.png"},
+	{"PACK MULE", BackpackSlot, []Modifier{{CarryWeightModifier, 1000, 120}}, map[string]float64{"Titanium": 100, "Rubber": 200, "Plastic": 60}, "images/iconbackpack3.png"},
+
+	// SALVAGE TOOLS
+	{"PLIERS", ToolSlot, []Modifier{{SalvageYieldModifier, 10, 2}}, map[string]float64{"Iron": 60, "Rubber": 20}, "images/icontool1.png"},
+	{"ANGLE GRINDER", ToolSlot, []Modifier{{SalvageYieldModifier, 20, 2}}, map[string]float64{"Steel": 120, "Copper": 60, "Plastic": 30}, "images/icontool2.png"},
+	{"SOLDERING KIT", ToolSlot, []Modifier{{SalvageYieldModifier, 15, 2}, {PrecisionModifier, 1, 0}}, map[string]float64{"Copper": 100, "Nickel": 40, "Plastic": 40}, "images/icontool3.png"},
+
+	// DETECTORS
+	{"BEEPER", DetectorSlot, []Modifier{{DetectionRangeModifier, 80, 15}}, map[string]float64{"Copper": 60, "Iron": 40, "Plastic": 20}, "images/icondetector1.png"},
+	{"DEEP SEEKER", DetectorSlot, []Modifier{{DetectionRangeModifier, 160, 25}}, map[string]float64{"Copper": 120, "Nickel": 40, "Plastic": 40}, "images/icondetector2.png"},
+}
+
+// CanAfford reports whether the materials cover every part of the recipe
+func (k KeyItemRecipe) CanAfford(materials map[string]int) bool {
+	return CanAfford(k.Materials, materials)
+}
+
+// GetModifier returns the value of the named modifier at level, 0 if the
+// key item doesn't have it
+func (k KeyItemRecipe) GetModifier(name string, level int) float64 {
+	for _, v := range k.Modifiers {
+		if v.Name == name {
+			return v.At(level)
+		}
+	}
+	return 0
+}
+
+// At is the value of the modifier once its key item reaches level
+func (m Modifier) At(level int) float64 {
+	return m.Value + m.PerLevel*float64(level-1)
+}
+
+// CanAfford reports whether the materials cover every part of the cost
+func CanAfford(cost map[string]float64, materials map[string]int) bool {
+	for key, amount := range cost {
+		if amount > float64(materials[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// CanUpgrade is true while a key item is below the max level and has a
+// modifier that grows with it
+func CanUpgrade(modifiers []Modifier, level int) bool {
+	if level >= MaxKeyItemLevel {
+		return false
+	}
+	for _, v := range modifiers {
+		if v.PerLevel != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// UpgradeCost is what taking a key item with recipe from level to the next
+// one costs
+func UpgradeCost(recipe map[string]float64, level int) map[string]float64 {
+	scale := upgradeCostBase + upgradeCostGrowth*float64(level-1)
+
+	cost := make(map[string]float64)
+	for k, v := range recipe {
+		cost[k] = math.Ceil(v * scale)
+	}
+	return cost
+}
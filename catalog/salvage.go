@@ -0,0 +1,42 @@
+package catalog
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Salvaging only recovers a share of the materials in a piece of junk,
+// the rest is lost. Salvage tools raise the share, the yield of a tool is
+// its salvage yield modifier in percent on top of the base yield. Tools
+// with the precision modifier can also get at byproducts that need one.
+const BaseSalvageYield = 0.8
+
+// FindJunk looks up the junk an inventory item was made from by name
+func FindJunk(name string) (Junk, bool) {
+	for _, v := range JunkList {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Junk{}, false
+}
+
+// SalvageAmount is how much of a material survives salvaging at yield
+func SalvageAmount(amount int, yield float64) int {
+	return int(math.Round(float64(amount) * yield))
+}
+
+// RollByproducts rolls each byproduct of the junk, leaving out the ones
+// that need a precision tool when precision is false
+func (j Junk) RollByproducts(rnd *rand.Rand, precision bool) map[string]int {
+	byproducts := make(map[string]int)
+	for _, v := range j.Byproducts {
+		if v.NeedPrecision && !precision {
+			continue
+		}
+		if rnd.Float64() < v.Chance {
+			byproducts[v.Name] += RollAmount(rnd, v.Min, v.Max)
+		}
+	}
+	return byproducts
+}
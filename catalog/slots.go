@@ -0,0 +1,56 @@
+package catalog
+
+// Slot is the equipment slot a key item goes in
+type Slot string
+
+const (
+	RodSlot           Slot = "Rod"
+	ReelSlot          Slot = "Reel"
+	LineSlot          Slot = "Line"
+	MagnetSlot        Slot = "Magnet"
+	BootsSlot         Slot = "Boots"
+	ElectromagnetSlot Slot = "Electromagnet"
+	RepulsorSlot      Slot = "Repulsor"
+	TankSlot          Slot = "Tank"
+	BackpackSlot      Slot = "Backpack"
+	ToolSlot          Slot = "Tool"
+	DetectorSlot      Slot = "Detector"
+)
+
+// SlotDefinition is everything about a slot apart from what is in it. X and
+// Y place it on the equipment panel, and the icon is shown faded while it
+// is empty. Slots added after the panel art was drawn need DrawFrame.
+// Effects are stats anything in the slot gives on top of its own modifiers,
+// and Wear is how fast using it wears the key item down.
+type SlotDefinition struct {
+	Slot         Slot
+	X, Y         float64
+	DrawFrame    bool
+	IconFilepath string
+	Effects      []Modifier
+	Wear         Wear
+}
+
+var Slots = []SlotDefinition{
+	{RodSlot, 233, 165, false, "images/iconrod1.png", nil, Wear{Cast: 1}},
+	{ReelSlot, 171, 317, false, "images/iconreel1.png", nil, Wear{Catch: 0.5, Strain: 3}},
+	{LineSlot, 381, 299, false, "images/iconline1.png", nil, Wear{Cast: 0.5, Strain: 4}},
+	{MagnetSlot, 350, 418, false, "images/iconmagnet1.png", nil, Wear{Catch: 1.5}},
+	{BootsSlot, 103, 534, false, "images/iconboots1.png", nil, Wear{}},
+	{ElectromagnetSlot, 29, 316, false, "images/iconelectromagnet.png", []Modifier{{ElectroMagnetModifier, 1, 0}}, Wear{}},
+	{RepulsorSlot, 69, 243, false, "images/iconrepulsor.png", []Modifier{{RepulsorModifier, 1, 0}}, Wear{}},
+	{TankSlot, 263, 520, true, "images/icontank1.png", nil, Wear{}},
+	{BackpackSlot, 345, 520, true, "images/iconbackpack1.png", nil, Wear{}},
+	{ToolSlot, 263, 438, true, "images/icontool1.png", nil, Wear{}},
+	{DetectorSlot, 29, 438, true, "images/icondetector1.png", nil, Wear{}},
+}
+
+// GetSlot returns the definition of a slot, false if it isn't registered
+func GetSlot(slot Slot) (SlotDefinition, bool) {
+	for _, v := range Slots {
+		if v.Slot == slot {
+			return v, true
+		}
+	}
+	return SlotDefinition{}, false
+}
@@ -0,0 +1,432 @@
+// Command balance simulates full runs of the game without a window and
+// reports how many dives it takes to craft each key item as CSV. The row
+// for the golden magnet is the number of dives to win.
+//
+// Junk spawning, material rolls, salvage yields and recipes come from the
+// catalog package so the numbers follow the game. Salvaging is treated as
+// instant, key items never wear down, as if repairs were free, and buried
+// junk is found as easily as the rest. Movement, aiming and the reel
+// minigame are stood in for by a bot whose behaviour is set with flags.
+//
+//	go run ./cmd/balance -runs 2000 -cast 0.8 -salvage hoard-gold > balance.csv
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mharv/scrapyard-charter/catalog"
+)
+
+// Starting stats, these mirror the initial values in data/playerData.go
+const (
+	initialMagneticFieldSize = 100
+	initialLineLength        = 400
+	initialMagnetCastSpeed   = 350
+	initialMagnetReelSpeed   = 400
+	initialScavengeTime      = 30
+	initialCarryWeight       = 500
+)
+
+// Scavenge pit layout, these mirror scenes/scavengeScene.go
+const (
+	junkPerDive      = 100
+	spawnZoneWidth   = 1366 - (128 * 4)
+	spawnZoneHeight  = 768 - (128 * 2)
+	depthSpread      = 0.3
+	rodTipX, rodTipY = 200, 0
+)
+
+const (
+	salvageAll       = "all"
+	salvageHoardGold = "hoard-gold"
+	craftEager       = "eager"
+	craftRush        = "rush"
+	targetNearest    = "nearest"
+	targetDeepest    = "deepest"
+)
+
+type policy struct {
+	castFraction float64
+	target       string
+	salvage      string
+	craft        string
+	reelSkill    float64
+	castOverhead float64
+}
+
+type pitJunk struct {
+	junk   int
+	x, y   float64
+	caught bool
+}
+
+type heldJunk struct {
+	junk      int
+	materials map[string]int
+	weight    float64
+}
+
+type player struct {
+	materials map[string]int
+	held      []heldJunk
+	owned     map[string]bool
+	equipped  map[catalog.Slot]catalog.KeyItemRecipe
+}
+
+func (p *player) heldWeight() float64 {
+	weight := 0.0
+	for _, v := range p.held {
+		weight += v.weight
+	}
+	return weight
+}
+
+// stat adds up the named modifier over everything equipped, the bot never
+// upgrades so key items stay at level 1
+func (p *player) stat(modifierName string, initial float64) float64 {
+	for _, v := range p.equipped {
+		initial += v.GetModifier(modifierName, 1)
+	}
+	return initial
+}
+
+// salvageTool mirrors PlayerData.GetSalvageTool
+func (p *player) salvageTool() (yield float64, precision bool) {
+	return p.stat(catalog.SalvageYieldModifier, catalog.BaseSalvageYield*100) / 100, p.stat(catalog.PrecisionModifier, 0) > 0
+}
+
+func main() {
+	runs := flag.Int("runs", 1000, "number of runs to simulate")
+	seed := flag.Int64("seed", 0, "random seed, 0 uses the current time")
+	maxDives := flag.Int("max-dives", 500, "give up on a run after this many dives")
+	raw := flag.Bool("raw", false, "write one row per run instead of a summary")
+	p := policy{}
+	flag.Float64Var(&p.castFraction, "cast", 1, "overworld cast distance as a fraction of the maximum, 0 to 1")
+	flag.StringVar(&p.target, "target", targetDeepest, "which reachable junk the bot casts at: nearest or deepest")
+	flag.StringVar(&p.salvage, "salvage", salvageAll, "all salvages every catch after a dive, hoard-gold keeps gold bearing junk until it covers the golden magnet")
+	flag.StringVar(&p.craft, "craft", craftEager, "eager crafts whenever something is affordable, rush only crafts the golden magnet")
+	flag.Float64Var(&p.reelSkill, "reel-skill", 0.5, "chance scale of winning the reel minigame, 1 never fails")
+	flag.Float64Var(&p.castOverhead, "cast-overhead", 1, "seconds spent aiming and dropping junk on each cast")
+	flag.Parse()
+
+	if p.castFraction < 0 || p.castFraction > 1 {
+		fmt.Fprintln(os.Stderr, "cast must be between 0 and 1")
+		os.Exit(2)
+	}
+	if p.target != targetNearest && p.target != targetDeepest {
+		fmt.Fprintln(os.Stderr, "unknown target policy", p.target)
+		os.Exit(2)
+	}
+	if p.salvage != salvageAll && p.salvage != salvageHoardGold {
+		fmt.Fprintln(os.Stderr, "unknown salvage policy", p.salvage)
+		os.Exit(2)
+	}
+	if p.craft != craftEager && p.craft != craftRush {
+		fmt.Fprintln(os.Stderr, "unknown craft policy", p.craft)
+		os.Exit(2)
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(*seed))
+
+	// dives taken to get each key item per run, missing if it never happened
+	results := make([]map[string]int, *runs)
+	for i := range results {
+		results[i] = simulateRun(rnd, p, *maxDives)
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	if *raw {
+		writeRaw(w, results)
+	} else {
+		writeSummary(w, results)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func simulateRun(rnd *rand.Rand, p policy, maxDives int) map[string]int {
+	pl := player{
+		materials: map[string]int{},
+		owned:     map[string]bool{},
+		equipped:  map[catalog.Slot]catalog.KeyItemRecipe{},
+	}
+	acquired := map[string]int{}
+
+	for dive := 1; dive <= maxDives && !pl.owned[catalog.WinningKeyItem]; dive++ {
+		simulateDive(rnd, p, &pl)
+		salvage(rnd, p, &pl)
+
+		if name, ok := craft(rnd, p, &pl); ok {
+			acquired[name] = dive
+		}
+	}
+
+	return acquired
+}
+
+func simulateDive(rnd *rand.Rand, p policy, pl *player) {
+	castPercent := p.castFraction * 100
+
+	pit := make([]pitJunk, junkPerDive)
+	for i := range pit {
+		j := catalog.PickJunk(rnd, catalog.JunkList, castPercent)
+		percent := catalog.JunkList[j].Depth/float64(len(catalog.JunkList)) + ((rnd.Float64() * depthSpread * 2) - depthSpread)
+		percent = math.Max(0, math.Min(1, percent))
+		pit[i] = pitJunk{junk: j, x: rnd.Float64() * spawnZoneWidth, y: percent * spawnZoneHeight}
+	}
+
+	field := pl.stat(catalog.MagnetFieldSizeModifier, initialMagneticFieldSize)
+	line := pl.stat(catalog.LineLengthModifier, initialLineLength)
+	castSpeed := pl.stat(catalog.CastSpeedModifier, initialMagnetCastSpeed)
+	reelSpeed := pl.stat(catalog.ReelSpeedModifier, initialMagnetReelSpeed)
+	timeLeft := pl.stat(catalog.DiveTimeModifier, initialScavengeTime)
+	carryWeight := pl.stat(catalog.CarryWeightModifier, initialCarryWeight)
+
+	for timeLeft > 0 {
+		target := chooseTarget(p, pit, line)
+		if target < 0 {
+			break
+		}
+
+		dist := math.Hypot(pit[target].x-rodTipX, pit[target].y-rodTipY)
+		timeLeft -= p.castOverhead + (dist / castSpeed) + (dist / reelSpeed)
+		if timeLeft < 0 {
+			break
+		}
+
+		// everything inside the field around the target comes up with it
+		for i := range pit {
+			if pit[i].caught || math.Abs(pit[i].x-pit[target].x) > field || math.Abs(pit[i].y-pit[target].y) > field {
+				continue
+			}
+			junk := catalog.JunkList[pit[i].junk]
+			if junk.ReelDifficulty > 0 && rnd.Float64() < junk.ReelDifficulty*(1-p.reelSkill) {
+				continue
+			}
+			pit[i].caught = true
+
+			if junk.TimeBonus > 0 {
+				timeLeft += junk.TimeBonus
+				continue
+			}
+
+			held := heldJunk{junk: pit[i].junk, materials: map[string]int{}}
+			for _, v := range junk.Materials {
+				held.materials[v.Name] = catalog.RollAmount(rnd, v.Min, v.Max)
+				held.weight += float64(held.materials[v.Name])
+			}
+
+			// the magnet leaves behind anything that would overfill the bag
+			if pl.heldWeight()+held.weight > carryWeight {
+				pit[i].caught = false
+				continue
+			}
+			pl.held = append(pl.held, held)
+		}
+	}
+}
+
+func chooseTarget(p policy, pit []pitJunk, line float64) int {
+	chosen := -1
+	best := 0.0
+	for i, v := range pit {
+		dist := math.Hypot(v.x-rodTipX, v.y-rodTipY)
+		if v.caught || dist > line {
+			continue
+		}
+		score := -dist
+		if p.target == targetDeepest {
+			score = catalog.JunkList[v.junk].Depth*spawnZoneHeight - dist
+		}
+		if chosen < 0 || score > best {
+			chosen = i
+			best = score
+		}
+	}
+	return chosen
+}
+
+func salvage(rnd *rand.Rand, p policy, pl *player) {
+	_, precision := pl.salvageTool()
+
+	if p.salvage == salvageHoardGold {
+		// gold is wiped along with everything else when something is
+		// crafted, so hold on to gold bearing junk until it adds up. Gold
+		// only comes out with a precision tool so keep it until then too.
+		expectedGold := 0.0
+		for _, v := range pl.held {
+			expectedGold += expectedByproduct(v.junk, "Gold", precision)
+		}
+		if !precision || expectedGold < catalog.WinningGold {
+			kept := []heldJunk{}
+			for _, v := range pl.held {
+				if hasGold(v.junk) {
+					kept = append(kept, v)
+					continue
+				}
+				addMaterials(rnd, pl, v)
+			}
+			pl.held = kept
+			return
+		}
+	}
+
+	for _, v := range pl.held {
+		addMaterials(rnd, pl, v)
+	}
+	pl.held = nil
+}
+
+func expectedByproduct(junk int, name string, precision bool) float64 {
+	expected := 0.0
+	for _, b := range catalog.JunkList[junk].Byproducts {
+		if b.Name == name && (precision || !b.NeedPrecision) {
+			expected += b.Chance * float64(b.Min+b.Max-1) / 2
+		}
+	}
+	return expected
+}
+
+func hasGold(junk int) bool {
+	for _, b := range catalog.JunkList[junk].Byproducts {
+		if b.Name == "Gold" {
+			return true
+		}
+	}
+	return false
+}
+
+// addMaterials follows Inventory.UpdateSalvage for one item
+func addMaterials(rnd *rand.Rand, pl *player, held heldJunk) {
+	yield, precision := pl.salvageTool()
+	for k, v := range held.materials {
+		pl.materials[k] += catalog.SalvageAmount(v, yield)
+	}
+	for k, v := range catalog.JunkList[held.junk].RollByproducts(rnd, precision) {
+		pl.materials[k] += v
+	}
+}
+
+// craft follows CraftingBench.CraftItem, a random affordable key item not
+// already owned, or the winning one when there is enough gold, and all
+// materials are used up
+func craft(rnd *rand.Rand, p policy, pl *player) (string, bool) {
+	craftable := []catalog.KeyItemRecipe{}
+	for _, v := range catalog.KeyItemRecipes {
+		if !pl.owned[v.Name] && v.CanAfford(pl.materials) {
+			craftable = append(craftable, v)
+		}
+	}
+	if len(craftable) == 0 {
+		return "", false
+	}
+
+	var chosen catalog.KeyItemRecipe
+	if pl.materials["Gold"] >= catalog.WinningGold {
+		for _, v := range craftable {
+			if v.Name == catalog.WinningKeyItem {
+				chosen = v
+			}
+		}
+	} else if p.craft == craftEager {
+		chosen = craftable[rnd.Intn(len(craftable))]
+	}
+	if chosen.Name == "" {
+		return "", false
+	}
+
+	pl.materials = map[string]int{}
+	pl.owned[chosen.Name] = true
+	if current, ok := pl.equipped[chosen.Type]; !ok || isBetter(chosen, current) {
+		pl.equipped[chosen.Type] = chosen
+	}
+
+	return chosen.Name, true
+}
+
+// isBetter picks which key item the bot keeps equipped, a precision tool
+// always wins as gold can't be salvaged without one
+func isBetter(chosen, current catalog.KeyItemRecipe) bool {
+	chosenPrecision := chosen.GetModifier(catalog.PrecisionModifier, 1) > 0
+	currentPrecision := current.GetModifier(catalog.PrecisionModifier, 1) > 0
+	if chosenPrecision != currentPrecision {
+		return chosenPrecision
+	}
+	return chosen.Modifiers[0].Value > current.Modifiers[0].Value
+}
+
+func writeRaw(w *csv.Writer, results []map[string]int) {
+	header := []string{"run"}
+	for _, v := range catalog.KeyItemRecipes {
+		header = append(header, v.Name)
+	}
+	w.Write(header)
+
+	for i, acquired := range results {
+		row := []string{strconv.Itoa(i + 1)}
+		for _, v := range catalog.KeyItemRecipes {
+			if dive, ok := acquired[v.Name]; ok {
+				row = append(row, strconv.Itoa(dive))
+			} else {
+				row = append(row, "")
+			}
+		}
+		w.Write(row)
+	}
+}
+
+func writeSummary(w *csv.Writer, results []map[string]int) {
+	w.Write([]string{"key_item", "type", "runs_reached", "share_reached", "mean", "min", "p10", "p50", "p90", "max"})
+
+	for _, v := range catalog.KeyItemRecipes {
+		dives := []int{}
+		for _, acquired := range results {
+			if dive, ok := acquired[v.Name]; ok {
+				dives = append(dives, dive)
+			}
+		}
+
+		row := []string{v.Name, string(v.Type), strconv.Itoa(len(dives)), strconv.FormatFloat(float64(len(dives))/float64(len(results)), 'f', 3, 64)}
+		if len(dives) == 0 {
+			row = append(row, "", "", "", "", "", "")
+		} else {
+			sort.Ints(dives)
+			total := 0
+			for _, d := range dives {
+				total += d
+			}
+			row = append(row,
+				strconv.FormatFloat(float64(total)/float64(len(dives)), 'f', 2, 64),
+				strconv.Itoa(dives[0]),
+				strconv.Itoa(percentile(dives, 0.1)),
+				strconv.Itoa(percentile(dives, 0.5)),
+				strconv.Itoa(percentile(dives, 0.9)),
+				strconv.Itoa(dives[len(dives)-1]),
+			)
+		}
+		w.Write(row)
+	}
+}
+
+// percentile uses the nearest rank of an already sorted slice
+func percentile(sorted []int, p float64) int {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
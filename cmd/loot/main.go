@@ -0,0 +1,80 @@
+// Command loot prints the chance of each junk type spawning in the
+// scavenge pit for a range of overworld cast distances, using the same
+// weights as the game.
+//
+//	go run ./cmd/loot -max-cast 350 -step 50
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/mharv/scrapyard-charter/catalog"
+)
+
+func main() {
+	maxCast := flag.Float64("max-cast", 200, "the players maximum overworld cast distance, 200 without a line")
+	step := flag.Float64("step", 25, "cast distance between columns")
+	asCSV := flag.Bool("csv", false, "write CSV instead of a table")
+	flag.Parse()
+
+	if *maxCast <= 0 || *step <= 0 {
+		fmt.Fprintln(os.Stderr, "max-cast and step must be above 0")
+		os.Exit(2)
+	}
+
+	distances := []float64{}
+	for d := 0.0; d < *maxCast; d += *step {
+		distances = append(distances, d)
+	}
+	distances = append(distances, *maxCast)
+
+	header := []string{"junk"}
+	for _, d := range distances {
+		header = append(header, strconv.FormatFloat(d, 'f', -1, 64))
+	}
+
+	rows := [][]string{}
+	for i, v := range catalog.JunkList {
+		rows = append(rows, []string{v.Name})
+		for _, d := range distances {
+			table := catalog.JunkTable(catalog.JunkList, (d / *maxCast)*100)
+			probability := table.GetProbability(i)
+			if *asCSV {
+				rows[i] = append(rows[i], strconv.FormatFloat(probability, 'f', 6, 64))
+			} else {
+				rows[i] = append(rows[i], fmt.Sprintf("%.2f%%", probability*100))
+			}
+		}
+	}
+
+	if *asCSV {
+		w := csv.NewWriter(os.Stdout)
+		w.Write(header)
+		w.WriteAll(rows)
+		if err := w.Error(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Println("Chance of each junk by overworld cast distance")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	writeTabbed(w, header)
+	for _, v := range rows {
+		writeTabbed(w, v)
+	}
+	w.Flush()
+}
+
+func writeTabbed(w *tabwriter.Writer, cells []string) {
+	for _, v := range cells {
+		fmt.Fprint(w, v, "\t")
+	}
+	fmt.Fprintln(w)
+}
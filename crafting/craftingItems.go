@@ -4,6 +4,7 @@ import (
 	"math/rand"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mharv/scrapyard-charter/catalog"
 	"github.com/mharv/scrapyard-charter/globals"
 	"github.com/mharv/scrapyard-charter/inventory"
 	"github.com/mharv/scrapyard-charter/resources"
@@ -14,9 +15,10 @@ type CraftingBench struct {
 	KeyItemsAvailable     []inventory.KeyItem
 }
 
+// CraftItem makes a key item out of the materials in the home base stash
 func (cb *CraftingBench) CraftItem() {
 	keyItemsObtainedCheck := globals.GetPlayerData().GetInventory().GetKeyItems()
-	materialsCollected := globals.GetPlayerData().GetInventory().GetMaterials()
+	materialsCollected := globals.GetPlayerData().GetStash().GetMaterials()
 
 	// use to store key items pool to randomly pick from
 	tempKeyItems := []inventory.KeyItem{}
@@ -61,10 +63,10 @@ func (cb *CraftingBench) CraftItem() {
 	if amountItemsCraftable > 0 {
 		var randomIndex int
 
-		if globals.GetPlayerData().GetInventory().GetMaterials()["Gold"] >= 50 {
+		if materialsCollected["Gold"] >= catalog.WinningGold {
 
 			for i, v := range tempKeyItems {
-				if v.GetKeyItemName() == "GOLDENMAGNET" {
+				if v.GetKeyItemName() == catalog.WinningKeyItem {
 					randomIndex = i
 				}
 			}
@@ -73,230 +75,117 @@ func (cb *CraftingBench) CraftItem() {
 			randomIndex = rand.Intn(amountItemsCraftable)
 		}
 
-		// remove all materials here
-		globals.GetPlayerData().GetInventory().ResetMaterials()
-		globals.GetPlayerData().GetInventory().AddKeyItem(tempKeyItems[randomIndex])
+		// remove all stashed materials here, what is carried is kept
+		globals.GetPlayerData().GetStash().ResetMaterials()
+		cb.AcquireKeyItem(tempKeyItems[randomIndex])
+	}
+}
 
-		// set new key item of type in
-		switch tempKeyItems[randomIndex].GetKeyItemType() {
-		case "Magnet":
+// AcquireKeyItem adds the key item to the inventory and flags its slot as
+// having something new, without checking or spending materials
+func (cb *CraftingBench) AcquireKeyItem(keyItem inventory.KeyItem) {
+	globals.GetPlayerData().GetInventory().AddKeyItem(keyItem)
+	globals.GetPlayerData().GetInventory().SetNewKeyItem(keyItem.GetKeyItemType())
+}
 
-			globals.GetPlayerData().GetInventory().NewMagnetAcquired = true
-		case "Rod":
+// UpgradeKeyItem raises a held key item a level, paying for it out of the
+// home base stash. Only what the upgrade costs is taken.
+func (cb *CraftingBench) UpgradeKeyItem(keyItem inventory.KeyItem) bool {
+	if !keyItem.CanUpgrade() {
+		return false
+	}
 
-			globals.GetPlayerData().GetInventory().NewRodAcquired = true
-		case "Reel":
+	cost := keyItem.GetUpgradeCost()
+	stash := globals.GetPlayerData().GetStash()
+	if !catalog.CanAfford(cost, stash.GetMaterials()) {
+		return false
+	}
 
-			globals.GetPlayerData().GetInventory().NewReelAcquired = true
-		case "Electromagnet":
+	for k, v := range cost {
+		stash.RemoveMaterial(k, int(v))
+	}
 
-			globals.GetPlayerData().GetInventory().NewElecAcquired = true
-		case "Repulsor":
+	upgraded, ok := globals.GetPlayerData().GetInventory().SetKeyItemLevel(keyItem.GetKeyItemName(), keyItem.GetLevel()+1)
+	if !ok {
+		return false
+	}
 
-			globals.GetPlayerData().GetInventory().NewRepAcquired = true
-		case "Boots":
+	reequip(upgraded)
+	return true
+}
 
-			globals.GetPlayerData().GetInventory().NewBootsAcquired = true
-		case "Line":
-			globals.GetPlayerData().GetInventory().NewLineAcquired = true
+// RepairKeyItem brings a held key item back to full durability, paying a
+// share of its recipe out of the home base stash that grows with the wear
+func (cb *CraftingBench) RepairKeyItem(keyItem inventory.KeyItem) bool {
+	if !keyItem.NeedsRepair() {
+		return false
+	}
+
+	cost := keyItem.GetRepairCost()
+	stash := globals.GetPlayerData().GetStash()
+	if !catalog.CanAfford(cost, stash.GetMaterials()) {
+		return false
+	}
+
+	for k, v := range cost {
+		stash.RemoveMaterial(k, int(v))
+	}
+
+	repaired, ok := globals.GetPlayerData().GetInventory().SetKeyItemDurability(keyItem.GetKeyItemName(), catalog.MaxDurability)
+	if !ok {
+		return false
+	}
+
+	reequip(repaired)
+	return true
+}
+
+// CraftConsumable makes a charge of a consumable out of the home base
+// stash, the charge goes straight into what the player carries
+func (cb *CraftingBench) CraftConsumable(recipe catalog.ConsumableRecipe) bool {
+	stash := globals.GetPlayerData().GetStash()
+	if !catalog.CanAfford(recipe.Materials, stash.GetMaterials()) {
+		return false
+	}
+
+	for k, v := range recipe.Materials {
+		stash.RemoveMaterial(k, int(v))
+	}
+
+	globals.GetPlayerData().GetInventory().AddConsumable(recipe.Name, 1)
+	return true
+}
+
+// equipped items are copies so the stats need equipping again
+func reequip(keyItem inventory.KeyItem) {
+	equipped, err := globals.GetPlayerData().GetEquippedItem(keyItem.GetKeyItemType())
+	if err == nil && equipped.GetKeyItemName() == keyItem.GetKeyItemName() {
+		globals.GetPlayerData().EquipItem(keyItem)
+	}
+}
+
+func (cb *CraftingBench) GetKeyItemByName(name string) (inventory.KeyItem, bool) {
+	for _, v := range cb.KeyItemsAvailable {
+		if v.GetKeyItemName() == name {
+			return v, true
 		}
 	}
+	return inventory.KeyItem{}, false
 }
 
 func (cb *CraftingBench) Init() {
 	// initialize list of key items that can be crafted here
-
-	// MAGNETS
-	magnet1 := &inventory.KeyItem{}
-	magnet1.Init(
-		"THE CLASSIC",
-		"Magnet",
-		inventory.KeyItemModifiers{ModifierName: "Magnet field size", ModifierValue: 50},
-		map[string]float64{"Iron": 50, "Nickel": 25, "Cobalt": 25},
-		LoadImage("images/iconmagnet1.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *magnet1)
-
-	magnet2 := &inventory.KeyItem{}
-	magnet2.Init(
-		"BABY BOY BLUE",
-		"Magnet",
-		inventory.KeyItemModifiers{ModifierName: "Magnet field size", ModifierValue: 100},
-		map[string]float64{"Steel": 75, "Nickel": 40, "Cobalt": 40},
-		LoadImage("images/iconmagnet2.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *magnet2)
-
-	magnet3 := &inventory.KeyItem{}
-	magnet3.Init(
-		"TITAN",
-		"Magnet",
-		inventory.KeyItemModifiers{ModifierName: "Magnet field size", ModifierValue: 200},
-		map[string]float64{"Steel": 100, "Titanium": 75, "Nickel": 40, "Cobalt": 40},
-		LoadImage("images/iconmagnet3.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *magnet3)
-
-	goldenMagnet := &inventory.KeyItem{}
-	goldenMagnet.Init(
-		"GOLDENMAGNET",
-		"Magnet",
-		inventory.KeyItemModifiers{ModifierName: "Magnet field size", ModifierValue: 999},
-		map[string]float64{"Gold": 50},
-		LoadImage("images/iconmagnetgold.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *goldenMagnet)
-
-	// DAS BOOTS
-
-	boots1 := &inventory.KeyItem{}
-	boots1.Init(
-		"GUM BOOTS",
-		"Boots",
-		inventory.KeyItemModifiers{ModifierName: "Move Speed", ModifierValue: 100},
-		map[string]float64{"Rubber": 100, "Iron": 100, "Plastic": 20},
-		LoadImage("images/iconboots1.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *boots1)
-
-	boots2 := &inventory.KeyItem{}
-	boots2.Init(
-		"TIM'S",
-		"Boots",
-		inventory.KeyItemModifiers{ModifierName: "Move Speed", ModifierValue: 200},
-		map[string]float64{"Rubber": 200, "Iron": 150, "Plastic": 40},
-		LoadImage("images/iconboots2.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *boots2)
-
-	boots3 := &inventory.KeyItem{}
-	boots3.Init(
-		"CUTE REDS",
-		"Boots",
-		inventory.KeyItemModifiers{ModifierName: "Move Speed", ModifierValue: 300},
-		map[string]float64{"Rubber": 300, "Iron": 200, "Plastic": 100},
-		LoadImage("images/iconboots3.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *boots3)
-
-	// RODS
-
-	rod1 := &inventory.KeyItem{}
-	rod1.Init(
-		"RODGER",
-		"Rod",
-		inventory.KeyItemModifiers{ModifierName: "Cast Speed", ModifierValue: 200},
-		map[string]float64{"Rubber": 100, "Iron": 50, "Plastic": 20},
-		LoadImage("images/iconrod1.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *rod1)
-
-	rod2 := &inventory.KeyItem{}
-	rod2.Init(
-		"RED ROCKET",
-		"Rod",
-		inventory.KeyItemModifiers{ModifierName: "Cast Speed", ModifierValue: 400},
-		map[string]float64{"Rubber": 200, "Steel": 100, "Plastic": 40},
-		LoadImage("images/iconrod2.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *rod2)
-
-	rod3 := &inventory.KeyItem{}
-	rod3.Init(
-		"PURPLE WHIP",
-		"Rod",
-		inventory.KeyItemModifiers{ModifierName: "Cast Speed", ModifierValue: 600},
-		map[string]float64{"Rubber": 300, "Titanium": 100, "Plastic": 60},
-		LoadImage("images/iconrod3.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *rod3)
-
-	// REELS
-
-	reel1 := &inventory.KeyItem{}
-	reel1.Init(
-		"REELY",
-		"Reel",
-		inventory.KeyItemModifiers{ModifierName: "Reel Speed", ModifierValue: 100},
-		map[string]float64{"Iron": 300},
-		LoadImage("images/iconreel1.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *reel1)
-
-	reel2 := &inventory.KeyItem{}
-	reel2.Init(
-		"WHITE WONDER",
-		"Reel",
-		inventory.KeyItemModifiers{ModifierName: "Reel Speed", ModifierValue: 200},
-		map[string]float64{"Steel": 300},
-		LoadImage("images/iconreel2.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *reel2)
-
-	reel3 := &inventory.KeyItem{}
-	reel3.Init(
-		"REALTY",
-		"Reel",
-		inventory.KeyItemModifiers{ModifierName: "Reel Speed", ModifierValue: 300},
-		map[string]float64{"Titanium": 300},
-		LoadImage("images/iconreel3.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *reel3)
-
-	// LINES
-	line1 := &inventory.KeyItem{}
-	line1.Init(
-		"LINE 'EM UP",
-		"Line",
-		inventory.KeyItemModifiers{ModifierName: "Line Length", ModifierValue: 150},
-		map[string]float64{"Rubber": 200},
-		LoadImage("images/iconline1.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *line1)
-
-	line2 := &inventory.KeyItem{}
-	line2.Init(
-		"FAIRY FLOSS",
-		"Line",
-		inventory.KeyItemModifiers{ModifierName: "Line Length", ModifierValue: 300},
-		map[string]float64{"Steel": 200},
-		LoadImage("images/iconline2.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *line2)
-
-	line3 := &inventory.KeyItem{}
-	line3.Init(
-		"LINE DANCER",
-		"Line",
-		inventory.KeyItemModifiers{ModifierName: "Line Length", ModifierValue: 450},
-		map[string]float64{"Copper": 200},
-		LoadImage("images/iconline3.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *line3)
-
-	// ELECTROMAGNET
-
-	Electrom := &inventory.KeyItem{}
-	Electrom.Init(
-		"ELECTRIFY",
-		"Electromagnet",
-		inventory.KeyItemModifiers{ModifierName: "Hold down 'Space'", ModifierValue: 1337},
-		map[string]float64{"Copper": 100},
-		LoadImage("images/iconelectromagnet.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *Electrom)
-
-	// REPULSOR
-
-	revpol := &inventory.KeyItem{}
-	revpol.Init(
-		"THE FUTURE",
-		"Repulsor",
-		inventory.KeyItemModifiers{ModifierName: "Use with 'Tab'", ModifierValue: 420},
-		map[string]float64{"Nickel": 30, "Cobalt": 30},
-		LoadImage("images/iconrepulsor.png"),
-	)
-	cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *revpol)
+	for _, v := range catalog.KeyItemRecipes {
+		keyItem := &inventory.KeyItem{}
+		keyItem.Init(
+			v.Name,
+			v.Type,
+			v.Modifiers,
+			v.Materials,
+			LoadImage(v.IconFilepath),
+		)
+		cb.KeyItemsAvailable = append(cb.KeyItemsAvailable, *keyItem)
+	}
 }
 
 func LoadImage(filepath string) *ebiten.Image {
@@ -6,48 +6,26 @@ import (
 	"math/rand"
 
 	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/catalog"
 	"github.com/mharv/scrapyard-charter/inventory"
+	"github.com/mharv/scrapyard-charter/stats"
 )
 
 type PlayerData struct {
 	inventory *inventory.Inventory
-	//ScavPlayerObject
-	scavMoveSpeedModifier float64
-	rodStartXModifier     float64
-	rodStartYModifier     float64
-	rodEndXModifier       float64
-	rodEndYModifier       float64
-	//MagnetObject
-	dropReactivationTimerModifier float64
-	magneticFieldSizeModifier     float64
-	attractionStrengthModifier    float64
-	lineLengthModifier            float64
-	magnetCastSpeedModifier       float64
-	magnetReelSpeedModifier       float64
-	HasElectroMagnetFlag          bool
-	HasRepulsorFlag               bool
+	// stash is kept at the home base, crafting takes from it
+	stash *inventory.Inventory
+	// stats hold everything equipment and buffs change, by modifier name
+	stats stats.Registry
 	//overworldPlayer
-	overworldMoveSpeedModifier    float64
-	overworldCastDistanceModifier float64
-	InitialOverworldPosition      basics.Vector2f
-	worldSeed                     int
-	overworldIsInCraftZone        bool
-	// itemSlots
-	reel   inventory.KeyItem
-	rod    inventory.KeyItem
-	line   inventory.KeyItem
-	magnet inventory.KeyItem
-	boots  inventory.KeyItem
-	elec   inventory.KeyItem
-	rep    inventory.KeyItem
-
-	isReelEquipped   bool
-	isRodEquipped    bool
-	isLineEquipped   bool
-	isMagnetEquipped bool
-	isBootsEquipped  bool
-	isElecEquipped   bool
-	isRepEquipped    bool
+	InitialOverworldPosition basics.Vector2f
+	worldSeed                int
+	overworldIsInCraftZone   bool
+	//scavenge results
+	bestDiveValue   int
+	bestDiveCatches int
+	// equipped holds the key item in each occupied slot
+	equipped map[catalog.Slot]inventory.KeyItem
 }
 
 const (
@@ -64,85 +42,91 @@ const (
 	initialLineLength            = 400
 	initialMagnetCastSpeed       = 350
 	initialMagnetReelSpeed       = 400
+	//ScavengeScene
+	initialScavengeTime = 30
+	//Inventory
+	initialCarryWeight = 500
+	// salvaging at the workbench is this many times faster
+	workbenchSalvageSpeed = 3
 	//overworldPlayer
 	initialOverworldMoveSpeed    = 200
 	initialOverworldCastDistance = 200
+
+	// equipment modifiers are grouped by the slot they come from
+	equipmentStatsID = "equipment:"
 )
 
+// EquipItem puts the key item in its slot and swaps the stat modifiers of
+// the item it replaces for its own
 func (p *PlayerData) EquipItem(item inventory.KeyItem) {
-	switch item.GetKeyItemType() {
-	case "Reel":
-		p.reel = item
-		p.isReelEquipped = true
-		p.magnetReelSpeedModifier = item.GetKeyItemModifiers().ModifierValue
-	case "Rod":
-		p.rod = item
-		p.isRodEquipped = true
-		p.magnetCastSpeedModifier = item.GetKeyItemModifiers().ModifierValue
-	case "Line":
-		p.line = item
-		p.isLineEquipped = true
-		p.lineLengthModifier = item.GetKeyItemModifiers().ModifierValue
-		p.overworldCastDistanceModifier = item.GetKeyItemModifiers().ModifierValue - 100 // balance patch
-	case "Magnet":
-		p.magnet = item
-		p.isMagnetEquipped = true
-		p.magneticFieldSizeModifier = item.GetKeyItemModifiers().ModifierValue
-	case "Boots":
-		p.boots = item
-		p.isBootsEquipped = true
-		p.overworldMoveSpeedModifier = item.GetKeyItemModifiers().ModifierValue
-		p.scavMoveSpeedModifier = item.GetKeyItemModifiers().ModifierValue
-	case "Repulsor":
-		p.rep = item
-		p.isRepEquipped = true
-		p.HasRepulsorFlag = true
-	case "Electromagnet":
-		p.elec = item
-		p.isElecEquipped = true
-		p.HasElectroMagnetFlag = true
+	slot, ok := catalog.GetSlot(item.GetKeyItemType())
+	if !ok {
+		return
 	}
+
+	p.equipped[slot.Slot] = item
+	p.stats.SetModifiers(equipmentStatsID+string(slot.Slot), keyItemStatModifiers(item, slot.Effects))
 }
 
-func (p *PlayerData) GetEquippedItem(slotName string) (inventory.KeyItem, error) {
-	switch slotName {
-	case "Reel":
-		return p.reel, nil
-	case "Rod":
-		return p.rod, nil
-	case "Line":
-		return p.line, nil
-	case "Magnet":
-		return p.magnet, nil
-	case "Boots":
-		return p.boots, nil
-	case "Repulsor":
-		return p.rep, nil
-	case "Electromagnet":
-		return p.elec, nil
+// keyItemStatModifiers turns the key items modifiers and the effects of
+// its slot into stat modifiers, what it gains from upgrades and loses to
+// wear is kept apart so the breakdown shows it
+func keyItemStatModifiers(item inventory.KeyItem, slotEffects []catalog.Modifier) []stats.Modifier {
+	modifiers := []stats.Modifier{}
+	for _, v := range item.GetModifiers() {
+		modifiers = append(modifiers, stats.Modifier{Stat: v.Name, Kind: stats.Add, Value: v.Value, Source: item.GetKeyItemName()})
+
+		if upgrade := v.At(item.GetLevel()) - v.Value; upgrade != 0 {
+			modifiers = append(modifiers, stats.Modifier{
+				Stat:   v.Name,
+				Kind:   stats.Add,
+				Value:  upgrade,
+				Source: fmt.Sprintf("%s Lv %d", item.GetKeyItemName(), item.GetLevel()),
+			})
+		}
+
+		if share := catalog.DurabilityShare(item.GetDurability()); share < 1 {
+			modifiers = append(modifiers, stats.Modifier{
+				Stat:   v.Name,
+				Kind:   stats.Add,
+				Value:  -v.At(item.GetLevel()) * (1 - share),
+				Source: item.GetKeyItemName() + " worn",
+			})
+		}
 	}
 
-	return inventory.KeyItem{}, errors.New("slotName does not exist")
-}
-
-func (p *PlayerData) CheckKeyItemTypeSlotIfOccupied(keyItemType string) bool {
-	switch keyItemType {
-	case "Reel":
-		return p.isReelEquipped
-	case "Rod":
-		return p.isRodEquipped
-	case "Line":
-		return p.isLineEquipped
-	case "Magnet":
-		return p.isMagnetEquipped
-	case "Boots":
-		return p.isBootsEquipped
-	case "Repulsor":
-		return p.isRepEquipped
-	case "Electromagnet":
-		return p.isElecEquipped
+	for _, v := range slotEffects {
+		modifiers = append(modifiers, stats.Modifier{Stat: v.Name, Kind: stats.Add, Value: v.Value, Source: item.GetKeyItemName()})
+	}
+	return modifiers
+}
+
+// WearEquipment takes durability off every equipped key item whose slot
+// wears from kind, amount times its wear rate
+func (p *PlayerData) WearEquipment(kind catalog.WearKind, amount float64) {
+	for _, v := range catalog.Slots {
+		item, ok := p.equipped[v.Slot]
+		if !ok || v.Wear.Get(kind) == 0 {
+			continue
+		}
+
+		// the inventory holds the copy that is saved
+		if worn, ok := p.inventory.SetKeyItemDurability(item.GetKeyItemName(), item.GetDurability()-v.Wear.Get(kind)*amount); ok {
+			p.EquipItem(worn)
+		}
 	}
-	return false
+}
+
+func (p *PlayerData) GetEquippedItem(slot catalog.Slot) (inventory.KeyItem, error) {
+	if _, ok := catalog.GetSlot(slot); !ok {
+		return inventory.KeyItem{}, errors.New("slotName does not exist")
+	}
+	return p.equipped[slot], nil
+}
+
+func (p *PlayerData) CheckKeyItemTypeSlotIfOccupied(slot catalog.Slot) bool {
+	_, ok := p.equipped[slot]
+	return ok
 }
 
 func (p *PlayerData) GetIndexOfEquippedKeyItem(keyItem inventory.KeyItem) int {
@@ -164,7 +148,36 @@ func (p *PlayerData) GetIndexOfEquippedKeyItem(keyItem inventory.KeyItem) int {
 func (p *PlayerData) Init() {
 	p.inventory = &inventory.Inventory{}
 	p.inventory.InitMaterials()
+	p.stash = &inventory.Inventory{}
+	p.stash.InitMaterials()
 	p.worldSeed = rand.Int()
+	p.equipped = make(map[catalog.Slot]inventory.KeyItem)
+	p.initStats()
+}
+
+func (p *PlayerData) initStats() {
+	p.stats.Init()
+	p.stats.Register(catalog.MagnetFieldSizeModifier, initialMagneticFieldSize, stats.StackAll)
+	p.stats.Register(catalog.AttractionModifier, initialAttractionStrength, stats.StackAll)
+	p.stats.Register(catalog.OverworldSpeedModifier, initialOverworldMoveSpeed, stats.StackAll)
+	p.stats.Register(catalog.DiveSpeedModifier, initialScavMoveSpeed, stats.StackAll)
+	p.stats.Register(catalog.CastSpeedModifier, initialMagnetCastSpeed, stats.StackAll)
+	p.stats.Register(catalog.ReelSpeedModifier, initialMagnetReelSpeed, stats.StackAll)
+	p.stats.Register(catalog.LineLengthModifier, initialLineLength, stats.StackAll)
+	p.stats.Register(catalog.CastDistanceModifier, initialOverworldCastDistance, stats.StackAll)
+	p.stats.Register(catalog.DiveTimeModifier, initialScavengeTime, stats.StackAll)
+	p.stats.Register(catalog.CarryWeightModifier, initialCarryWeight, stats.StackAll)
+	p.stats.Register(catalog.SalvageYieldModifier, catalog.BaseSalvageYield*100, stats.StackAll)
+	// a second precise tool is no more precise
+	p.stats.Register(catalog.PrecisionModifier, 0, stats.StackHighest)
+	p.stats.Register(catalog.ElectroMagnetModifier, 0, stats.StackHighest)
+	p.stats.Register(catalog.RepulsorModifier, 0, stats.StackHighest)
+	p.stats.Register(catalog.DetectionRangeModifier, 0, stats.StackAll)
+}
+
+// GetStats is the registry behind every stat getter, buffs go straight in
+func (p *PlayerData) GetStats() *stats.Registry {
+	return &p.stats
 }
 
 // func (p *PlayerData) Update() error {
@@ -192,6 +205,26 @@ func (p *PlayerData) GetWorldSeed() int {
 	return p.worldSeed
 }
 
+func (p *PlayerData) SetWorldSeed(seed int) {
+	p.worldSeed = seed
+}
+
+func (p *PlayerData) GetBestDiveValue() int {
+	return p.bestDiveValue
+}
+
+func (p *PlayerData) SetBestDiveValue(value int) {
+	p.bestDiveValue = value
+}
+
+func (p *PlayerData) GetBestDiveCatches() int {
+	return p.bestDiveCatches
+}
+
+func (p *PlayerData) SetBestDiveCatches(catches int) {
+	p.bestDiveCatches = catches
+}
+
 func (p *PlayerData) GetPlayerPosition() basics.Vector2f {
 	return p.InitialOverworldPosition
 }
@@ -203,62 +236,108 @@ func (p *PlayerData) GetInventory() *inventory.Inventory {
 	return p.inventory
 }
 
+func (p *PlayerData) GetStash() *inventory.Inventory {
+	return p.stash
+}
+
 func (p *PlayerData) GetOverworldCastDistance() float64 {
-	return initialOverworldCastDistance + p.overworldCastDistanceModifier
+	return p.stats.Get(catalog.CastDistanceModifier)
 }
 
 func (p *PlayerData) GetOverworldMoveSpeed() float64 {
-	return initialOverworldMoveSpeed + p.overworldMoveSpeedModifier
+	return p.stats.Get(catalog.OverworldSpeedModifier)
 }
 
 func (p *PlayerData) GetScavMoveSpeed() float64 {
-	return initialScavMoveSpeed + p.scavMoveSpeedModifier
+	return p.stats.Get(catalog.DiveSpeedModifier)
 }
 
 func (p *PlayerData) GetRodStartX() float64 {
-	return initialRodStartX + p.rodStartXModifier
+	return initialRodStartX
 }
 
 func (p *PlayerData) GetRodStartY() float64 {
-	return initialRodStartY + p.rodStartYModifier
+	return initialRodStartY
 }
 
 func (p *PlayerData) GetRodEndX() float64 {
-	return initialRodEndX + p.rodEndXModifier
+	return initialRodEndX
 }
 
 func (p *PlayerData) GetRodEndY() float64 {
-	return initialRodEndY + p.rodEndYModifier
+	return initialRodEndY
 }
 
 func (p *PlayerData) GetDropReactivationTimer() float64 {
-	return initialDropReactivationTimer + p.dropReactivationTimerModifier
+	return initialDropReactivationTimer
 }
 
 func (p *PlayerData) GetMagneticFieldSize() float64 {
-	return initialMagneticFieldSize + p.magneticFieldSizeModifier
+	return p.stats.Get(catalog.MagnetFieldSizeModifier)
 }
 
 func (p *PlayerData) GetAttractionStrength() float64 {
-	return initialAttractionStrength + p.attractionStrengthModifier
+	return p.stats.Get(catalog.AttractionModifier)
 }
 
 func (p *PlayerData) GetLineLength() float64 {
-	return initialLineLength + p.lineLengthModifier
+	return p.stats.Get(catalog.LineLengthModifier)
 }
 
 func (p *PlayerData) GetMagnetCastSpeed() float64 {
-	return initialMagnetCastSpeed + p.magnetCastSpeedModifier
+	return p.stats.Get(catalog.CastSpeedModifier)
 }
 
 func (p *PlayerData) GetMagnetReelSpeed() float64 {
-	return initialMagnetReelSpeed + p.magnetReelSpeedModifier
+	return p.stats.Get(catalog.ReelSpeedModifier)
+}
+
+func (p *PlayerData) GetScavengeTime() float64 {
+	return p.stats.Get(catalog.DiveTimeModifier)
+}
+
+// GetDetectionRange is how close buried junk has to be to the magnet for
+// the detector to find it, 0 without one
+func (p *PlayerData) GetDetectionRange() float64 {
+	return p.stats.Get(catalog.DetectionRangeModifier)
+}
+
+func (p *PlayerData) GetCarryWeight() float64 {
+	return p.stats.Get(catalog.CarryWeightModifier)
+}
+
+// CanCarry reports whether the item fits in what is left of the carry weight
+func (p *PlayerData) CanCarry(item inventory.Item) bool {
+	return p.inventory.GetItemWeight()+item.GetWeight() <= p.GetCarryWeight()
+}
+
+// IsBagFull is true once the carried items reach the carry weight, nothing
+// more can be caught until some are salvaged
+func (p *PlayerData) IsBagFull() bool {
+	return p.inventory.GetItemWeight() >= p.GetCarryWeight()
+}
+
+// GetSalvageTool is what the player is salvaging with right now
+func (p *PlayerData) GetSalvageTool() inventory.SalvageTool {
+	tool := inventory.SalvageTool{
+		Yield:     p.stats.Get(catalog.SalvageYieldModifier) / 100,
+		Precision: p.stats.Get(catalog.PrecisionModifier) > 0,
+		Speed:     1,
+	}
+	if p.overworldIsInCraftZone {
+		tool.Speed = workbenchSalvageSpeed
+	}
+	return tool
+}
+
+func (p *PlayerData) UpdateSalvage(deltaTime float64) {
+	p.inventory.UpdateSalvage(deltaTime, p.GetSalvageTool())
 }
 
 func (p *PlayerData) HasElectroMagnet() bool {
-	return p.HasElectroMagnetFlag
+	return p.stats.Get(catalog.ElectroMagnetModifier) > 0
 }
 
 func (p *PlayerData) HasRepulsor() bool {
-	return p.HasRepulsorFlag
+	return p.stats.Get(catalog.RepulsorModifier) > 0
 }
@@ -0,0 +1,170 @@
+package data
+
+import (
+	"encoding/json"
+
+	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/inventory"
+	"github.com/mharv/scrapyard-charter/storage"
+)
+
+const (
+	saveFileName = "save.json"
+)
+
+type saveData struct {
+	WorldSeed         int
+	Position          basics.Vector2f
+	Materials         map[string]int
+	Items             []savedItem
+	StashMaterials    map[string]int
+	StashItems        []savedItem
+	KeyItems          []string
+	KeyItemLevels     map[string]int
+	KeyItemDurability map[string]float64
+	EquippedItems     []string
+	Consumables       map[string]int
+	BestDiveValue     int
+	BestDiveCatches   int
+}
+
+type savedItem struct {
+	Name        string
+	Depth       float64
+	Rarity      float64
+	RarityScale float64
+	Materials   map[string]int
+}
+
+// Key items hold images so only their names, levels and durability are
+// saved, the lookup turns a name back into the crafted key item when loading
+type KeyItemLookup func(name string) (inventory.KeyItem, bool)
+
+func HasSave() bool {
+	return storage.Exists(saveFileName)
+}
+
+func (p *PlayerData) Save() error {
+	save := saveData{
+		WorldSeed:         p.worldSeed,
+		Position:          p.InitialOverworldPosition,
+		Materials:         p.inventory.GetMaterials(),
+		Items:             saveItems(p.inventory),
+		StashMaterials:    p.stash.GetMaterials(),
+		StashItems:        saveItems(p.stash),
+		BestDiveValue:     p.bestDiveValue,
+		BestDiveCatches:   p.bestDiveCatches,
+		KeyItemLevels:     make(map[string]int),
+		KeyItemDurability: make(map[string]float64),
+		Consumables:       p.inventory.GetConsumables(),
+	}
+
+	for _, v := range p.inventory.GetKeyItems() {
+		save.KeyItems = append(save.KeyItems, v.GetKeyItemName())
+		save.KeyItemLevels[v.GetKeyItemName()] = v.GetLevel()
+		save.KeyItemDurability[v.GetKeyItemName()] = v.GetDurability()
+
+		equipped, err := p.GetEquippedItem(v.GetKeyItemType())
+		if err == nil && equipped.GetKeyItemName() == v.GetKeyItemName() {
+			save.EquippedItems = append(save.EquippedItems, v.GetKeyItemName())
+		}
+	}
+
+	bs, err := json.Marshal(save)
+	if err != nil {
+		return err
+	}
+	return storage.Write(saveFileName, bs)
+}
+
+func (p *PlayerData) Load(lookup KeyItemLookup) error {
+	bs, err := storage.Read(saveFileName)
+	if err != nil {
+		return err
+	}
+
+	save := saveData{}
+	if err := json.Unmarshal(bs, &save); err != nil {
+		return err
+	}
+
+	*p = PlayerData{}
+	p.Init()
+	p.worldSeed = save.WorldSeed
+	p.InitialOverworldPosition = save.Position
+	p.bestDiveValue = save.BestDiveValue
+	p.bestDiveCatches = save.BestDiveCatches
+
+	for k, v := range save.Materials {
+		p.inventory.AddMaterial(k, v)
+	}
+
+	loadItems(p.inventory, save.Items)
+
+	for k, v := range save.StashMaterials {
+		p.stash.AddMaterial(k, v)
+	}
+	loadItems(p.stash, save.StashItems)
+
+	for k, v := range save.Consumables {
+		p.inventory.AddConsumable(k, v)
+	}
+
+	for _, v := range save.KeyItems {
+		if keyItem, ok := lookup(v); ok {
+			// saves from before upgrades have no levels, SetLevel keeps
+			// those at level 1
+			keyItem.SetLevel(save.KeyItemLevels[v])
+			// and no durability, those load as good as new
+			if durability, ok := save.KeyItemDurability[v]; ok {
+				keyItem.SetDurability(durability)
+			}
+			p.inventory.AddKeyItem(keyItem)
+		}
+	}
+
+	for _, v := range save.EquippedItems {
+		for _, keyItem := range p.inventory.GetKeyItems() {
+			if keyItem.GetKeyItemName() == v {
+				p.EquipItem(keyItem)
+			}
+		}
+	}
+
+	return nil
+}
+
+// saveItems saves items still waiting to be salvaged as held items, they
+// go back in the bag unsalvaged when loaded
+func saveItems(inv *inventory.Inventory) []savedItem {
+	items := []savedItem{}
+	for _, v := range append(inv.GetItems(), inv.GetSalvageQueue()...) {
+		item := savedItem{
+			Name:        v.GetName(),
+			Depth:       v.GetDepth(),
+			Rarity:      v.GetRarity(),
+			RarityScale: v.GetRarityScale(),
+			Materials:   make(map[string]int),
+		}
+		for k, m := range v.GetMaterials() {
+			item.Materials[k] = m.GetAmount()
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func loadItems(inv *inventory.Inventory, items []savedItem) {
+	for _, v := range items {
+		item := inventory.Item{}
+		item.Init()
+		item.SetName(v.Name)
+		item.SetDepth(v.Depth)
+		item.SetRarity(v.Rarity)
+		item.SetRarityScale(v.RarityScale)
+		for k, amount := range v.Materials {
+			item.SetRawMaterialAmount(k, amount)
+		}
+		inv.AddItem(item)
+	}
+}
@@ -18,6 +18,12 @@ type Entity interface {
 	RemovePhysObj(space *resolv.Space)
 }
 
+// Interpolator can be implemented by entities that draw somewhere between
+// where they were before the last step and where they are now
+type Interpolator interface {
+	Interpolate(alpha float64)
+}
+
 type EntityManager struct {
 	entities     []Entity
 	deadEntities []Entity
@@ -49,6 +55,14 @@ func (e *EntityManager) RemoveDead(space *resolv.Space) {
 	}
 }
 
+func (e *EntityManager) Interpolate(alpha float64) {
+	for _, entity := range e.entities {
+		if interpolator, ok := entity.(Interpolator); ok {
+			interpolator.Interpolate(alpha)
+		}
+	}
+}
+
 func (e *EntityManager) Draw(screen *ebiten.Image) {
 	for _, entity := range e.entities {
 		entity.Draw(screen)
@@ -59,3 +73,7 @@ func (e *EntityManager) Draw(screen *ebiten.Image) {
 func (e *EntityManager) AddEntity(entity Entity) {
 	e.entities = append(e.entities, entity)
 }
+
+func (e *EntityManager) GetEntityCount() int {
+	return len(e.entities)
+}
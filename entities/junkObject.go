@@ -2,6 +2,7 @@ package entities
 
 import (
 	"image/color"
+	"math"
 	"math/rand"
 	"time"
 
@@ -15,17 +16,23 @@ import (
 )
 
 type JunkObject struct {
-	sprite        *ebiten.Image
-	physObj       *resolv.Object
-	itemData      inventory.Item
-	audioFilepath []string
-	imageFilepath string
-	rot           float64
-	alive         bool
+	sprite         *ebiten.Image
+	physObj        *resolv.Object
+	itemData       inventory.Item
+	audioFilepath  []string
+	imageFilepath  string
+	rot            float64
+	reelDifficulty float64
+	timeBonus      float64
+	revealTime     float64
+	buried         bool
+	alive          bool
 }
 
 const (
 	junkPhysObjSizeDiff = 15
+	// unearthed junk glows for this long so the player sees what turned up
+	unearthRevealTime = 1
 )
 
 func (j *JunkObject) GetPhysObj() *resolv.Object {
@@ -40,9 +47,15 @@ func (j *JunkObject) AddAudioFile(AudioFilepath string) {
 	j.audioFilepath = append(j.audioFilepath, AudioFilepath)
 }
 
+func (j *JunkObject) GetAudioFiles() []string {
+	return j.audioFilepath
+}
+
+// Plays one of the junk's sounds panned towards where it sits on screen
 func (j *JunkObject) PlayAudio() {
 	rnd := rand.Intn(len(j.audioFilepath))
-	globals.GetAudioPlayer().PlayFile(j.audioFilepath[rnd])
+	pan := ((j.physObj.X+(j.physObj.W/2))/globals.ScreenWidth)*2 - 1
+	globals.GetAudioPlayer().PlaySFXAt(j.audioFilepath[rnd], pan)
 }
 
 func (j *JunkObject) Init(ImageFilepath string) {
@@ -65,6 +78,9 @@ func (j *JunkObject) ReadInput() {
 }
 
 func (j *JunkObject) Update(deltaTime float64) {
+	if j.revealTime > 0 {
+		j.revealTime -= deltaTime
+	}
 	j.physObj.Update()
 }
 
@@ -81,6 +97,15 @@ func (j *JunkObject) Draw(screen *ebiten.Image) {
 		ebitenutil.DrawRect(screen, j.physObj.X, j.physObj.Y, j.physObj.W, j.physObj.H, color.RGBA{0, 80, 255, 64})
 	}
 
+	// revealed junk glows, fading out as the reveal runs out
+	if j.revealTime > 0 {
+		ebitenutil.DrawRect(screen, j.physObj.X, j.physObj.Y, j.physObj.W, j.physObj.H, color.RGBA{197, 204, 184, uint8(160 * math.Min(j.revealTime, 1))})
+	}
+
+	if j.buried {
+		options.ColorM.Scale(0.3, 0.3, 0.3, 0.6)
+	}
+
 	// Draw the image (comment this out to see the above resolv rect ^^^)
 	screen.DrawImage(j.sprite, options)
 }
@@ -124,13 +149,57 @@ func (j *JunkObject) GetImageFilepath() string {
 	return j.imageFilepath
 }
 
+// Junk with a reel difficulty above zero starts the reel-in minigame
+// when hooked, 1 being the hardest
+func (j *JunkObject) SetReelDifficulty(difficulty float64) {
+	j.reelDifficulty = difficulty
+}
+
+func (j *JunkObject) GetReelDifficulty() float64 {
+	return j.reelDifficulty
+}
+
+// Junk with a time bonus adds seconds to the dive when caught
+// instead of going into the inventory
+func (j *JunkObject) SetTimeBonus(seconds float64) {
+	j.timeBonus = seconds
+}
+
+func (j *JunkObject) GetTimeBonus() float64 {
+	return j.timeBonus
+}
+
+// Reveal makes the junk stand out for a number of seconds, digging it up
+// if it was buried
+func (j *JunkObject) Reveal(seconds float64) {
+	j.revealTime = seconds
+	j.buried = false
+}
+
+// Buried junk is drawn darkened and can't be caught or attracted until
+// something unearths it
+func (j *JunkObject) Bury() {
+	j.buried = true
+}
+
+func (j *JunkObject) IsBuried() bool {
+	return j.buried
+}
+
+func (j *JunkObject) Unearth() {
+	if j.buried {
+		j.Reveal(unearthRevealTime)
+	}
+}
+
 func (j *JunkObject) AddItemDataMaterial(materialName string, minQuantity, maxQuantity int) {
 	j.itemData.AddRawMaterial(materialName, minQuantity, maxQuantity)
 }
 
 func (j *JunkObject) RandomiseAllMaterialValues() {
-	for _, value := range j.itemData.GetMaterials() {
+	for key, value := range j.itemData.GetMaterials() {
 		value.RandomiseAmount()
+		j.itemData.GetMaterials()[key] = value
 	}
 }
 
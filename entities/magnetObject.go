@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"fmt"
 	"image/color"
 	"math"
 
@@ -8,9 +9,14 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/catalog"
 	"github.com/mharv/scrapyard-charter/globals"
+	"github.com/mharv/scrapyard-charter/inventory"
 	"github.com/mharv/scrapyard-charter/resources"
+	"github.com/mharv/scrapyard-charter/settings"
+	"github.com/mharv/scrapyard-charter/stats"
 	"github.com/solarlune/resolv"
+	"github.com/tinne26/etxt"
 )
 
 type MagnetObject struct {
@@ -22,6 +28,8 @@ type MagnetObject struct {
 	powerOnIconSprite  *ebiten.Image
 	powerOffIconSprite *ebiten.Image
 	powerIconBgSprite  *ebiten.Image
+	consumableIcons    map[string]*ebiten.Image
+	txtRenderer        *etxt.Renderer
 	physObj            *resolv.Object
 	targetObj          *resolv.Object
 	magFieldPhysObj    *resolv.Object
@@ -47,10 +55,22 @@ type MagnetObject struct {
 	alive              bool
 	dropCounter        float64
 	junkLookup         map[*resolv.Object]*JunkObject
+	reelMinigame       ReelMinigame
+	castCount          int
+	sessionCatches     []inventory.Item
+	timeBonus          float64
+	bagFull            bool
+	// seconds left before each consumable can be used again this dive
+	cooldowns map[string]float64
 }
 
 const (
 	magPhysObjSizeDiff = 20
+	// consumables sit in a row under the electromagnet and repulsor icons
+	hotbarIconSize   = 64
+	hotbarIconGap    = 2
+	hotbarFontSize   = 20
+	consumableBuffID = "consumable:"
 )
 
 func (m *MagnetObject) GetFishingLinePoint() basics.Vector2f {
@@ -86,6 +106,102 @@ func (m *MagnetObject) GetSprite() *ebiten.Image {
 	return m.sprite
 }
 
+func (m *MagnetObject) GetCastCount() int {
+	return m.castCount
+}
+
+func (m *MagnetObject) GetSessionCatches() []inventory.Item {
+	return m.sessionCatches
+}
+
+// IsBagFull is true once the magnet has had to leave junk behind because
+// it would not fit in the players carry weight
+func (m *MagnetObject) IsBagFull() bool {
+	return m.bagFull
+}
+
+// firstCarryable returns the first junk touched that the player has room
+// for, time bonus junk always fits
+func (m *MagnetObject) firstCarryable(objects []*resolv.Object) *resolv.Object {
+	for _, v := range objects {
+		junk, ok := m.junkLookup[v]
+		if !ok || junk.IsBuried() {
+			continue
+		}
+		if junk.GetTimeBonus() > 0 || globals.GetPlayerData().CanCarry(*junk.GetItemData()) {
+			return v
+		}
+		m.bagFull = true
+	}
+	return nil
+}
+
+// Returns the seconds collected from time bonus junk since the last call
+func (m *MagnetObject) TakeTimeBonus() float64 {
+	bonus := m.timeBonus
+	m.timeBonus = 0
+	return bonus
+}
+
+// UseConsumable spends a charge of the named consumable if it is off
+// cooldown, returning false if it couldn't be used
+func (m *MagnetObject) UseConsumable(name string) bool {
+	recipe, ok := catalog.GetConsumable(name)
+	if !ok || m.cooldowns[name] > 0 {
+		return false
+	}
+	if !globals.GetPlayerData().GetInventory().UseConsumable(name) {
+		return false
+	}
+
+	switch name {
+	case catalog.MagneticPulse:
+		globals.GetPlayerData().GetStats().AddBuff(consumableBuffID+name, []stats.Modifier{
+			{Stat: catalog.MagnetFieldSizeModifier, Kind: stats.Multiply, Value: catalog.PulseFieldMultiplier, Source: name},
+			{Stat: catalog.AttractionModifier, Kind: stats.Multiply, Value: catalog.PulseAttraction, Source: name},
+		}, catalog.PulseDuration)
+	case catalog.SonarPing:
+		for _, v := range m.junkLookup {
+			if v.IsAlive() && v.GetItemData().GetRarity() <= catalog.SonarMaxRarity {
+				v.Reveal(catalog.SonarDuration)
+			}
+		}
+	case catalog.TimeExtender:
+		m.timeBonus += catalog.TimeExtenderTime
+	}
+
+	m.cooldowns[name] = recipe.Cooldown
+	return true
+}
+
+// EndDive removes the buffs consumables put on the player stats, they only
+// last for the dive they were used in
+func (m *MagnetObject) EndDive() {
+	for _, v := range catalog.ConsumableRecipes {
+		globals.GetPlayerData().GetStats().RemoveModifiers(consumableBuffID + v.Name)
+	}
+}
+
+// IsPulsing is true while a magnetic pulse is pulling junk in, even with
+// the magnet back on the rod
+func (m *MagnetObject) IsPulsing() bool {
+	return globals.GetPlayerData().GetStats().GetBuffTimeLeft(consumableBuffID+catalog.MagneticPulse) > 0
+}
+
+// refreshField picks up changes to the magnet stats from buffs running
+// out or starting, resizing the field around the magnet
+func (m *MagnetObject) refreshField() {
+	m.attractionStrength = globals.GetPlayerData().GetAttractionStrength()
+
+	size := globals.GetPlayerData().GetMagneticFieldSize()
+	if size == m.magneticFieldSize {
+		return
+	}
+	m.magneticFieldSize = size
+	m.magFieldPhysObj.W = float64(m.sprite.Bounds().Dx()) + (size * 2)
+	m.magFieldPhysObj.H = float64(m.sprite.Bounds().Dy()) + (size * 2)
+}
+
 func (m *MagnetObject) Init(ImageFilepath string) {
 	m.alive = true
 
@@ -103,6 +219,8 @@ func (m *MagnetObject) Init(ImageFilepath string) {
 	m.magneticFieldSize = globals.GetPlayerData().GetMagneticFieldSize()
 	m.attractionStrength = globals.GetPlayerData().GetAttractionStrength()
 
+	m.cooldowns = make(map[string]float64)
+
 	m.magFieldPhysObj = resolv.NewObject(-m.magneticFieldSize, -m.magneticFieldSize, float64(m.sprite.Bounds().Dx())+(m.magneticFieldSize*2), float64(m.sprite.Bounds().Dy())+(m.magneticFieldSize*2), "magneticField")
 
 	m.magneticPoint.X = float64(magPhysObjSizeDiff / 2)
@@ -116,6 +234,7 @@ func (m *MagnetObject) Init(ImageFilepath string) {
 	m.syncToRod = true
 	m.turnedOn = true
 	m.dropCounter = 0
+	m.bagFull = false
 	m.rotation = float64(float64(90) / float64(180) * math.Pi)
 	m.imageRotation = m.rotation
 }
@@ -134,28 +253,37 @@ func (m *MagnetObject) ReadInput() {
 		m.attractedJunk = nil
 
 		m.magnetEndPos = end
+		m.castCount++
+		globals.GetPlayerData().WearEquipment(catalog.CastWear, 1)
 		m.magnetActive = true
 		m.retract = false
 		m.syncToRod = false
 	}
 
+	m.reelMinigame.ReadInput()
+
 	if globals.GetPlayerData().HasElectroMagnet() {
-		if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		if inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionElectroMagnet)) {
 			m.turnedOn = false
 		}
-		if inpututil.IsKeyJustReleased(ebiten.KeySpace) {
+		if inpututil.IsKeyJustReleased(globals.GetSettings().GetKey(settings.ActionElectroMagnet)) {
 			m.turnedOn = true
 		}
 	}
 
 	if globals.GetPlayerData().HasRepulsor() {
-		if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		if inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionRepulsor)) {
 			m.repulsor = !m.repulsor
 		}
 	}
 }
 
 func (m *MagnetObject) Update(deltaTime float64) {
+	for k, v := range m.cooldowns {
+		m.cooldowns[k] = math.Max(v-deltaTime, 0)
+	}
+	m.refreshField()
+
 	trackingPoint := basics.Vector2f{X: m.magnetStartPos.X - (m.physObj.W / 2), Y: m.magnetStartPos.Y}
 
 	if m.syncToRod {
@@ -170,8 +298,12 @@ func (m *MagnetObject) Update(deltaTime float64) {
 		m.dropCounter -= deltaTime
 	} else {
 		if !m.connected && m.turnedOn {
+			var touched *resolv.Object
 			if collision := m.physObj.Check(dx, dy, "junk"); collision != nil {
-				m.connectedJunk = collision.Objects[0]
+				touched = m.firstCarryable(collision.Objects)
+			}
+			if touched != nil {
+				m.connectedJunk = touched
 				m.linkDistance.X = m.connectedJunk.X - m.physObj.X
 				m.linkDistance.Y = m.connectedJunk.Y - m.physObj.Y
 				m.touch = true
@@ -180,6 +312,9 @@ func (m *MagnetObject) Update(deltaTime float64) {
 				v := basics.Vector2f{X: m.connectedJunk.X, Y: m.connectedJunk.Y}
 				m.rotation = m.RotateTo(v)
 				m.junkLookup[m.connectedJunk].PlayAudio()
+				if difficulty := m.junkLookup[m.connectedJunk].GetReelDifficulty(); difficulty > 0 {
+					m.reelMinigame.Start(difficulty)
+				}
 			} else {
 				m.touch = false
 			}
@@ -195,22 +330,42 @@ func (m *MagnetObject) Update(deltaTime float64) {
 			m.retract = true
 		}
 	}
+	if m.reelMinigame.IsActive() {
+		m.reelMinigame.Update(deltaTime)
+		if m.reelMinigame.IsStraining() {
+			globals.GetPlayerData().WearEquipment(catalog.StrainWear, deltaTime)
+		}
+		if m.reelMinigame.HasFailed() {
+			m.Drop()
+		}
+	}
+
 	if m.retract {
 		if basics.FloatDistance(*m.magnetPos, trackingPoint) >= 5 && m.retract {
-			newPos := m.MoveTowards(*m.magnetPos, trackingPoint, globals.GetPlayerData().GetMagnetReelSpeed()*deltaTime)
+			reelSpeed := globals.GetPlayerData().GetMagnetReelSpeed() * m.reelMinigame.GetReelSpeedScale()
+			newPos := m.MoveTowards(*m.magnetPos, trackingPoint, reelSpeed*deltaTime)
 			m.magnetPos.X += newPos.X
 			m.magnetPos.Y += newPos.Y
 		} else {
 			m.syncToRod = true
 			m.magnetActive = false
+			m.reelMinigame.End()
 			if m.connected {
 				m.connected = false
+				globals.GetPlayerData().WearEquipment(catalog.CatchWear, 1)
 
 				if val, ok := m.junkLookup[m.connectedJunk]; ok {
 					if val.IsAlive() {
-						globals.GetPlayerData().GetInventory().AddItem(*val.GetItemData())
-
-						val.Kill()
+						if val.GetTimeBonus() > 0 {
+							m.timeBonus += val.GetTimeBonus()
+							val.Kill()
+						} else if globals.GetPlayerData().CanCarry(*val.GetItemData()) {
+							globals.GetPlayerData().GetInventory().AddItem(*val.GetItemData())
+							m.sessionCatches = append(m.sessionCatches, *val.GetItemData())
+							val.Kill()
+						} else {
+							m.bagFull = true
+						}
 					}
 				}
 
@@ -232,13 +387,21 @@ func (m *MagnetObject) Update(deltaTime float64) {
 		m.rotation = m.RotateTo(r)
 	}
 
-	if m.magnetActive && m.turnedOn {
+	// the field unearths buried junk it passes over even while switched off
+	if m.magnetActive || m.IsPulsing() {
 		if collision := m.magFieldPhysObj.Check(dx, dy, "junk"); collision != nil {
-			m.attractedJunk = collision.Objects
+			m.unearth(collision.Objects)
+			if m.turnedOn {
+				m.attractedJunk = collision.Objects
+			}
+		}
+		if m.turnedOn {
+			m.MoveAttractedJunk(deltaTime)
 		}
-		m.MoveAttractedJunk(deltaTime)
 	}
 
+	m.detect()
+
 	fieldOffset := basics.Vector2f{X: -m.magneticFieldSize - (magPhysObjSizeDiff / 2), Y: -m.magneticFieldSize - (magPhysObjSizeDiff / 2)}
 
 	m.SetObjPos(m.targetObj, m.targetPos)
@@ -283,6 +446,8 @@ func (m *MagnetObject) Draw(screen *ebiten.Image) {
 	screen.DrawImage(m.sprite, sop)
 	screen.DrawImage(m.targetSprite, top)
 
+	m.reelMinigame.Draw(screen, basics.Vector2f{X: m.physObj.X + m.physObj.W, Y: m.physObj.Y + (m.physObj.H / 2)})
+
 	uiop := &ebiten.DrawImageOptions{}
 	uiop.GeoM.Translate(m.UIPos.X, m.UIPos.Y)
 	if globals.GetPlayerData().HasElectroMagnet() {
@@ -303,6 +468,45 @@ func (m *MagnetObject) Draw(screen *ebiten.Image) {
 			screen.DrawImage(m.attractIconSprite, uiop)
 		}
 	}
+
+	m.drawHotbar(screen)
+}
+
+// drawHotbar shows each consumable the player has charges of, darkened
+// from the top by how much of its cooldown is left
+func (m *MagnetObject) drawHotbar(screen *ebiten.Image) {
+	m.txtRenderer.SetTarget(screen)
+	x := m.UIPos.X
+	y := m.UIPos.Y + float64(m.powerIconBgSprite.Bounds().Dy()) + hotbarIconGap
+
+	for i, v := range catalog.ConsumableRecipes {
+		charges := globals.GetPlayerData().GetInventory().GetConsumableCount(v.Name)
+		if charges <= 0 && m.cooldowns[v.Name] <= 0 {
+			continue
+		}
+
+		iop := &ebiten.DrawImageOptions{}
+		iop.GeoM.Translate(x, y)
+		screen.DrawImage(m.powerIconBgSprite, iop)
+		if charges <= 0 {
+			iop.ColorM.Scale(0.5, 0.5, 0.5, 1)
+		}
+		screen.DrawImage(m.consumableIcons[v.Name], iop)
+
+		if cooldown := m.cooldowns[v.Name]; cooldown > 0 {
+			ebitenutil.DrawRect(screen, x, y, hotbarIconSize, hotbarIconSize*cooldown/v.Cooldown, color.RGBA{40, 38, 36, 160})
+		}
+
+		m.txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+		m.txtRenderer.SetAlign(etxt.Top, etxt.Left)
+		if i < len(settings.HotbarActions) {
+			m.txtRenderer.Draw(globals.GetSettings().GetKey(settings.HotbarActions[i]).String(), int(x)+4, int(y))
+		}
+		m.txtRenderer.SetAlign(etxt.Bottom, etxt.Right)
+		m.txtRenderer.Draw(fmt.Sprintf("x%d", charges), int(x)+hotbarIconSize-4, int(y)+hotbarIconSize)
+
+		x += hotbarIconSize + hotbarIconGap
+	}
 }
 
 func (m *MagnetObject) IsAlive() bool {
@@ -324,6 +528,19 @@ func (m *MagnetObject) LoadUIImages() {
 	m.powerOnIconSprite = loadImage("images/onicon.png")
 	m.powerOffIconSprite = loadImage("images/officon.png")
 	m.powerIconBgSprite = loadImage("images/powericonborder.png")
+
+	m.consumableIcons = make(map[string]*ebiten.Image)
+	for _, v := range catalog.ConsumableRecipes {
+		m.consumableIcons[v.Name] = loadImage(v.IconFilepath)
+	}
+
+	fontLib := resources.LoadFileAsFont("fonts/Rajdhani-Regular.ttf")
+
+	m.txtRenderer = etxt.NewStdRenderer()
+	glyphsCache := etxt.NewDefaultCache(1024 * 1024) // 1MB
+	m.txtRenderer.SetCacheHandler(glyphsCache.NewHandler())
+	m.txtRenderer.SetFont(fontLib.GetFont("Rajdhani Regular"))
+	m.txtRenderer.SetSizePx(hotbarFontSize)
 }
 
 func loadImage(filepath string) *ebiten.Image {
@@ -335,6 +552,7 @@ func (m *MagnetObject) SetUIPos(pos basics.Vector2f) {
 }
 
 func (m *MagnetObject) Drop() {
+	m.reelMinigame.End()
 	m.connectedJunk = nil
 	m.linkDistance = basics.Vector2f{X: 0, Y: 0}
 	m.connected = false
@@ -366,6 +584,33 @@ func (m *MagnetObject) MoveAttractedJunk(deltaTime float64) {
 	}
 }
 
+func (m *MagnetObject) unearth(objects []*resolv.Object) {
+	for _, v := range objects {
+		if junk, ok := m.junkLookup[v]; ok {
+			junk.Unearth()
+		}
+	}
+}
+
+// detect unearths buried junk within the detector range of the magnet
+func (m *MagnetObject) detect() {
+	detectionRange := globals.GetPlayerData().GetDetectionRange()
+	if detectionRange <= 0 {
+		return
+	}
+
+	centre := basics.Vector2f{X: m.physObj.X + (m.physObj.W / 2), Y: m.physObj.Y + (m.physObj.H / 2)}
+	for _, v := range m.junkLookup {
+		if !v.IsAlive() || !v.IsBuried() {
+			continue
+		}
+		junkCentre := basics.Vector2f{X: v.GetPhysObj().X + (v.GetPhysObj().W / 2), Y: v.GetPhysObj().Y + (v.GetPhysObj().H / 2)}
+		if basics.FloatDistance(centre, junkCentre) <= detectionRange {
+			v.Unearth()
+		}
+	}
+}
+
 func (m *MagnetObject) SetJunkLookup(Lookup map[*resolv.Object]*JunkObject) {
 	m.junkLookup = Lookup
 }
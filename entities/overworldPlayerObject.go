@@ -8,13 +8,17 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/mharv/scrapyard-charter/animation"
 	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/catalog"
 	"github.com/mharv/scrapyard-charter/globals"
+	"github.com/mharv/scrapyard-charter/settings"
+	"github.com/mharv/scrapyard-charter/stats"
 	"github.com/solarlune/resolv"
 )
 
 type OverworldPlayerObject struct {
 	animator                              animation.Animator
 	physObj                               *resolv.Object
+	previousPos                           basics.Vector2f
 	entityManager                         EntityManager
 	moveUp, moveDown, moveRight, moveLeft bool
 	moveSpeed                             float64
@@ -25,6 +29,9 @@ type OverworldPlayerObject struct {
 const (
 	frameSizeX = 48
 	frameSizeY = 80
+
+	runStatsID         = "run"
+	runSpeedMultiplier = 2
 )
 
 func (p *OverworldPlayerObject) GetPhysObj() *resolv.Object {
@@ -63,6 +70,8 @@ func (p *OverworldPlayerObject) Init(ImageFilepath string) {
 	p.animator.SetAnimation("idleRight", false)
 
 	playerData := globals.GetPlayerData()
+	// a run held when the last scene ended shouldn't carry over
+	playerData.GetStats().RemoveModifiers(runStatsID)
 
 	p.moveSpeed = playerData.GetOverworldMoveSpeed()
 	p.CastDistanceLimit = playerData.GetOverworldCastDistance()
@@ -73,49 +82,58 @@ func (p *OverworldPlayerObject) Init(ImageFilepath string) {
 func (p *OverworldPlayerObject) ReadInput() {
 	p.entityManager.ReadInput()
 
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
-		p.moveSpeed *= 2
+	if inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionRun)) {
+		globals.GetPlayerData().GetStats().SetModifiers(runStatsID, []stats.Modifier{
+			{Stat: catalog.OverworldSpeedModifier, Kind: stats.Multiply, Value: runSpeedMultiplier, Source: "Running"},
+		})
+		p.moveSpeed = globals.GetPlayerData().GetOverworldMoveSpeed()
 	}
-	if inpututil.IsKeyJustReleased(ebiten.KeySpace) {
+	if inpututil.IsKeyJustReleased(globals.GetSettings().GetKey(settings.ActionRun)) {
+		globals.GetPlayerData().GetStats().RemoveModifiers(runStatsID)
 		p.moveSpeed = globals.GetPlayerData().GetOverworldMoveSpeed()
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyW) {
+	if inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionMoveUp)) {
 		p.move = true
 		p.moveUp = true
 	}
-	if inpututil.IsKeyJustReleased(ebiten.KeyW) {
+	if inpututil.IsKeyJustReleased(globals.GetSettings().GetKey(settings.ActionMoveUp)) {
 		p.moveUp = false
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyA) {
+	if inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionMoveLeft)) {
 		p.flip = false
 		p.move = true
 		p.moveLeft = true
 	}
-	if inpututil.IsKeyJustReleased(ebiten.KeyA) {
+	if inpututil.IsKeyJustReleased(globals.GetSettings().GetKey(settings.ActionMoveLeft)) {
 		p.moveLeft = false
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+	if inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionMoveDown)) {
 		p.move = true
 		p.moveDown = true
 	}
-	if inpututil.IsKeyJustReleased(ebiten.KeyS) {
+	if inpututil.IsKeyJustReleased(globals.GetSettings().GetKey(settings.ActionMoveDown)) {
 		p.moveDown = false
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyD) {
+	if inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionMoveRight)) {
 		p.flip = true
 		p.move = true
 		p.moveRight = true
 	}
-	if inpututil.IsKeyJustReleased(ebiten.KeyD) {
+	if inpututil.IsKeyJustReleased(globals.GetSettings().GetKey(settings.ActionMoveRight)) {
 		p.moveRight = false
 	}
 }
 
 func (p *OverworldPlayerObject) Update(deltaTime float64) {
+	// updates with no time passing aren't steps, the player is still drawn
+	// from where it was before the last one
+	if deltaTime > 0 {
+		p.previousPos = basics.Vector2f{X: p.physObj.X, Y: p.physObj.Y}
+	}
 
 	var dx, dy float64
 
@@ -191,6 +209,13 @@ func (p *OverworldPlayerObject) Update(deltaTime float64) {
 	p.animator.Update(basics.Vector2f{X: p.physObj.X, Y: p.physObj.Y - p.physObj.H}, deltaTime)
 }
 
+// Interpolate draws the player part way from where it was before the last
+// step, so movement stays smooth when drawing faster than the TPS
+func (p *OverworldPlayerObject) Interpolate(alpha float64) {
+	pos := basics.Vec2FLerp(p.previousPos, basics.Vector2f{X: p.physObj.X, Y: p.physObj.Y}, alpha)
+	p.animator.SetPosition(basics.Vector2f{X: pos.X, Y: pos.Y - p.physObj.H})
+}
+
 func (p *OverworldPlayerObject) Draw(screen *ebiten.Image) {
 	// Debug drawing of the physics object
 	if globals.Debug {
@@ -203,6 +228,7 @@ func (p *OverworldPlayerObject) Draw(screen *ebiten.Image) {
 func (p *OverworldPlayerObject) SetPosition(position basics.Vector2f) {
 	p.physObj.X = position.X
 	p.physObj.Y = position.Y
+	p.previousPos = position
 }
 
 func (p *OverworldPlayerObject) GetCellPosition() (x, y int) {
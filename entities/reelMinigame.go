@@ -0,0 +1,147 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/globals"
+)
+
+// ReelMinigame is the moving sweet-spot bar shown while heavy or rare
+// junk is being reeled in. Holding the left mouse button lifts the needle,
+// releasing it lets the needle fall. Keeping the needle inside the sweet spot
+// reels at full speed, leaving it builds strain until the junk is dropped.
+type ReelMinigame struct {
+	difficulty    float64
+	sweetSpotPos  float64
+	sweetSpotDir  float64
+	sweetSpotSize float64
+	needlePos     float64
+	needleSpeed   float64
+	strain        float64
+	holding       bool
+	active        bool
+}
+
+const (
+	reelBarWidth           = 24
+	reelBarHeight          = 160
+	reelBarMagnetOffset    = 24
+	reelStrainBarWidth     = 6
+	reelSweetSpotBaseSize  = 0.35
+	reelSweetSpotBaseSpeed = 0.4
+	reelNeedleLift         = 2.5
+	reelNeedleGravity      = 2
+	reelStrainRate         = 0.6
+	reelStrainRelief       = 0.3
+	reelOffSpotSpeedScale  = 0.2
+)
+
+func (r *ReelMinigame) Start(difficulty float64) {
+	r.difficulty = basics.FloatClamp(difficulty, 0, 1)
+	r.sweetSpotSize = reelSweetSpotBaseSize * (1 - (r.difficulty * 0.6))
+	r.sweetSpotPos = 0.5
+	r.sweetSpotDir = 1
+	r.needlePos = 0.5
+	r.needleSpeed = 0
+	r.strain = 0
+	r.holding = false
+	r.active = true
+}
+
+func (r *ReelMinigame) End() {
+	r.active = false
+}
+
+func (r *ReelMinigame) IsActive() bool {
+	return r.active
+}
+
+func (r *ReelMinigame) IsInSweetSpot() bool {
+	return math.Abs(r.needlePos-r.sweetSpotPos) <= r.sweetSpotSize/2
+}
+
+// IsStraining is true while a catch is being reeled off the sweet spot
+func (r *ReelMinigame) IsStraining() bool {
+	return r.active && !r.IsInSweetSpot()
+}
+
+func (r *ReelMinigame) HasFailed() bool {
+	return r.strain >= 1
+}
+
+// Returns the multiplier applied to the magnet reel speed this frame
+func (r *ReelMinigame) GetReelSpeedScale() float64 {
+	if !r.active || r.IsInSweetSpot() {
+		return 1
+	}
+	return reelOffSpotSpeedScale
+}
+
+func (r *ReelMinigame) ReadInput() {
+	r.holding = ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+}
+
+func (r *ReelMinigame) Update(deltaTime float64) {
+	if !r.active {
+		return
+	}
+
+	// sweet spot bounces between the ends of the bar, faster for harder junk
+	r.sweetSpotPos += r.sweetSpotDir * reelSweetSpotBaseSpeed * (0.5 + r.difficulty) * deltaTime
+	if r.sweetSpotPos+(r.sweetSpotSize/2) >= 1 {
+		r.sweetSpotPos = 1 - (r.sweetSpotSize / 2)
+		r.sweetSpotDir = -1
+	}
+	if r.sweetSpotPos-(r.sweetSpotSize/2) <= 0 {
+		r.sweetSpotPos = r.sweetSpotSize / 2
+		r.sweetSpotDir = 1
+	}
+
+	if r.holding {
+		r.needleSpeed += reelNeedleLift * deltaTime
+	} else {
+		r.needleSpeed -= reelNeedleGravity * deltaTime
+	}
+	r.needlePos += r.needleSpeed * deltaTime
+	if r.needlePos <= 0 || r.needlePos >= 1 {
+		r.needlePos = basics.FloatClamp(r.needlePos, 0, 1)
+		r.needleSpeed = 0
+	}
+
+	if r.IsInSweetSpot() {
+		r.strain -= reelStrainRelief * deltaTime
+	} else {
+		r.strain += reelStrainRate * (0.5 + r.difficulty) * deltaTime
+	}
+	r.strain = basics.FloatClamp(r.strain, 0, 1)
+}
+
+// Draws the bar to the side of the given position, positions along the bar
+// run bottom (0) to top (1)
+func (r *ReelMinigame) Draw(screen *ebiten.Image, position basics.Vector2f) {
+	if !r.active {
+		return
+	}
+
+	x := basics.FloatClamp(position.X+reelBarMagnetOffset, 0, globals.ScreenWidth-(reelBarWidth+reelStrainBarWidth+2))
+	y := basics.FloatClamp(position.Y-(reelBarHeight/2), 0, globals.ScreenHeight-reelBarHeight)
+
+	ebitenutil.DrawRect(screen, x, y, reelBarWidth, reelBarHeight, color.RGBA{40, 38, 36, 200})
+
+	spotColor := color.RGBA{110, 105, 98, 255}
+	if r.IsInSweetSpot() {
+		spotColor = color.RGBA{197, 204, 184, 255}
+	}
+	spotTop := y + (1-(r.sweetSpotPos+(r.sweetSpotSize/2)))*reelBarHeight
+	ebitenutil.DrawRect(screen, x, spotTop, reelBarWidth, r.sweetSpotSize*reelBarHeight, spotColor)
+
+	needleY := y + (1-r.needlePos)*reelBarHeight
+	ebitenutil.DrawRect(screen, x-2, needleY-2, reelBarWidth+4, 4, color.RGBA{154, 79, 80, 255})
+
+	strainHeight := r.strain * reelBarHeight
+	ebitenutil.DrawRect(screen, x+reelBarWidth+2, y+reelBarHeight-strainHeight, reelStrainBarWidth, strainHeight, color.RGBA{154, 79, 80, 255})
+}
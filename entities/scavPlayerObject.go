@@ -9,6 +9,7 @@ import (
 	"github.com/mharv/scrapyard-charter/animation"
 	"github.com/mharv/scrapyard-charter/basics"
 	"github.com/mharv/scrapyard-charter/globals"
+	"github.com/mharv/scrapyard-charter/settings"
 	"github.com/solarlune/resolv"
 )
 
@@ -84,17 +85,17 @@ func (s *ScavPlayerObject) Init(ImageFilepath string) {
 }
 
 func (s *ScavPlayerObject) ReadInput() {
-	if inpututil.IsKeyJustPressed(ebiten.KeyA) {
+	if inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionMoveLeft)) {
 		s.left = true
 	}
-	if inpututil.IsKeyJustReleased(ebiten.KeyA) {
+	if inpututil.IsKeyJustReleased(globals.GetSettings().GetKey(settings.ActionMoveLeft)) {
 		s.left = false
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyD) {
+	if inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionMoveRight)) {
 		s.right = true
 	}
-	if inpututil.IsKeyJustReleased(ebiten.KeyD) {
+	if inpututil.IsKeyJustReleased(globals.GetSettings().GetKey(settings.ActionMoveRight)) {
 		s.right = false
 	}
 }
@@ -2,33 +2,30 @@ package game
 
 import (
 	_ "image/png"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mharv/scrapyard-charter/gameTime"
 	"github.com/mharv/scrapyard-charter/globals"
 	"github.com/mharv/scrapyard-charter/scenes"
 )
 
-var (
-	newTime, oldTime int64
-)
-
 type Game struct {
 	sceneManager *scenes.SceneManager
+	clock        gameTime.Clock
 }
 
 func (g *Game) Update() error {
-	oldTime = newTime
-	newTime = time.Now().UnixNano()
-	deltaTime := float64((newTime-oldTime)/1000000) * 0.001
-
 	if g.sceneManager == nil {
-		g.sceneManager = &scenes.SceneManager{}
+		g.clock.Init()
+		g.sceneManager = &scenes.SceneManager{Clock: &g.clock}
 		g.sceneManager.GoTo(&scenes.TitleScene{}, 0)
 	}
 
+	g.clock.Tick()
+	globals.GetAudioPlayer().Update(g.clock.GetStep())
+
 	g.sceneManager.ReadInput()
-	if err := g.sceneManager.Update(deltaTime); err != nil {
+	if err := g.sceneManager.Update(); err != nil {
 		return err
 	}
 
@@ -44,8 +41,9 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeigh
 }
 
 func (g *Game) Init() {
-	ebiten.SetWindowSize(globals.ScreenWidth, globals.ScreenHeight)
 	ebiten.SetWindowTitle("Scrapyard Charter")
 	globals.GetPlayerData().Init()
+	globals.InitSettings()
 	globals.InitAudioPlayer()
+	globals.ApplySettings()
 }
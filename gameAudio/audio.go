@@ -6,35 +6,200 @@ import (
 )
 
 const (
-	sampleRate = 44100
+	sampleRate    = 44100
+	musicFadeTime = 1
 )
 
+// Bus groups sounds that share a volume and mute setting
+type Bus struct {
+	volume float64
+	muted  bool
+}
+
+func (b *Bus) GetVolume() float64 {
+	return b.volume
+}
+
+func (b *Bus) IsMuted() bool {
+	return b.muted
+}
+
+// Returns the volume players on the bus should be set to
+func (b *Bus) gain() float64 {
+	if b.muted {
+		return 0
+	}
+	return b.volume
+}
+
 type Audio struct {
 	audioContext *audio.Context
-	audioPlayer  map[string]*audio.Player
+	// music is streamed, players only exist while a track is audible
+	musicPlayers map[string]*audio.Player
+	music        Bus
+	sfx          Bus
+	currentMusic string
+	// fade level of every music track still audible, the current track
+	// fades in while the rest fade out
+	musicFades map[string]float64
+	// decoded sound effects shared by every voice playing them
+	samples    map[string][]byte
+	voices     []*voice
+	lastPlayed map[string]float64
+	time       float64
 }
 
 func (a *Audio) Init() {
 	a.audioContext = audio.NewContext(sampleRate)
-	a.audioPlayer = make(map[string]*audio.Player)
+	a.musicPlayers = make(map[string]*audio.Player)
+	a.music = Bus{volume: 1}
+	a.sfx = Bus{volume: 1}
+	a.currentMusic = ""
+	a.musicFades = make(map[string]float64)
+	a.samples = make(map[string][]byte)
+	a.voices = []*voice{}
+	a.lastPlayed = make(map[string]float64)
+	a.time = 0
+}
+
+// Update moves music crossfades along, deltaTime is unscaled so music
+// keeps fading while the game is paused
+func (a *Audio) Update(deltaTime float64) {
+	a.time += deltaTime
+	a.updateVoices()
+
+	for k, v := range a.musicFades {
+		if k == a.currentMusic {
+			v += deltaTime / musicFadeTime
+			if v > 1 {
+				v = 1
+			}
+		} else {
+			v -= deltaTime / musicFadeTime
+		}
+
+		if v <= 0 {
+			a.musicPlayers[k].Close()
+			delete(a.musicPlayers, k)
+			delete(a.musicFades, k)
+			continue
+		}
+
+		a.musicFades[k] = v
+		a.musicPlayers[k].SetVolume(v * a.music.gain())
+	}
+}
+
+// PlayMusic crossfades to the given track, calling it again with the
+// current track restarts it once it has finished
+func (a *Audio) PlayMusic(filepath string) {
+	if filepath == a.currentMusic {
+		if !a.musicPlayers[filepath].IsPlaying() {
+			a.musicPlayers[filepath].Rewind()
+			a.musicPlayers[filepath].Play()
+		}
+		return
+	}
+
+	a.currentMusic = filepath
+
+	// a track that is still fading out picks up where it is
+	if _, ok := a.musicPlayers[filepath]; !ok {
+		p, err := a.audioContext.NewPlayer(resources.LoadFileAsStream(filepath))
+		if err != nil {
+			panic("Cannot create player for: " + filepath)
+		}
+		p.SetVolume(0)
+		a.musicPlayers[filepath] = p
+		a.musicFades[filepath] = 0
+	}
+	a.musicPlayers[filepath].Play()
+}
+
+func (a *Audio) StopMusic() {
+	a.currentMusic = ""
 }
 
-func (a *Audio) LoadFiles(folder string) {
-	a.audioPlayer = resources.LoadFolderAsAudio(folder, a.audioContext)
+// sound effects are decoded the first time they are played unless a
+// scene asks for them up front
+func (a *Audio) loadSample(filepath string) []byte {
+	pcm := resources.LoadFileAsPCM(filepath)
+	a.samples[filepath] = pcm
+	return pcm
 }
 
-func (a *Audio) PlayFile(filepath string) {
-	if !a.audioPlayer[filepath].IsPlaying() {
-		a.audioPlayer[filepath].Rewind()
-		a.audioPlayer[filepath].Play()
+// PreloadSFX decodes the given sound effects now so the first play
+// doesn't stall the frame
+func (a *Audio) PreloadSFX(filepaths []string) {
+	for _, v := range filepaths {
+		if _, ok := a.samples[v]; !ok {
+			a.loadSample(v)
+		}
 	}
 }
 
-func (a *Audio) StopAllAudio() {
-	for _, v := range a.audioPlayer {
-		if v.IsPlaying() {
-			v.Pause()
-			v.Rewind()
+// ReleaseSFX drops every cached sound effect except the ones to keep,
+// voices still playing a released sound finish normally
+func (a *Audio) ReleaseSFX(keep []string) {
+	kept := make(map[string]bool)
+	for _, v := range keep {
+		kept[v] = true
+	}
+
+	for k := range a.samples {
+		if !kept[k] {
+			delete(a.samples, k)
+			delete(a.lastPlayed, k)
 		}
 	}
 }
+
+func (a *Audio) GetMusicBus() Bus {
+	return a.music
+}
+
+func (a *Audio) GetSFXBus() Bus {
+	return a.sfx
+}
+
+func (a *Audio) SetMusicVolume(volume float64) {
+	a.music.volume = clampVolume(volume)
+	a.applyMusicVolume()
+}
+
+func (a *Audio) SetMusicMuted(muted bool) {
+	a.music.muted = muted
+	a.applyMusicVolume()
+}
+
+func (a *Audio) SetSFXVolume(volume float64) {
+	a.sfx.volume = clampVolume(volume)
+	a.applySFXVolume()
+}
+
+func (a *Audio) SetSFXMuted(muted bool) {
+	a.sfx.muted = muted
+	a.applySFXVolume()
+}
+
+func (a *Audio) applyMusicVolume() {
+	for k, v := range a.musicFades {
+		a.musicPlayers[k].SetVolume(v * a.music.gain())
+	}
+}
+
+func (a *Audio) applySFXVolume() {
+	for _, v := range a.voices {
+		v.player.SetVolume(a.sfx.gain() * v.attenuation)
+	}
+}
+
+func clampVolume(volume float64) float64 {
+	if volume < 0 {
+		return 0
+	}
+	if volume > 1 {
+		return 1
+	}
+	return volume
+}
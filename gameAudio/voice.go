@@ -0,0 +1,137 @@
+package gameAudio
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+const (
+	maxVoices = 16
+	// 16 bit stereo
+	bytesPerFrame = 4
+	// sounds at the edge of the screen play this much quieter than centred ones
+	panAttenuation = 0.4
+	// replaying a sample within this many seconds counts as a repeated hit
+	repeatWindow   = 0.5
+	repeatPitchVar = 0.08
+)
+
+// voice is one playing instance of a sample
+type voice struct {
+	player      *audio.Player
+	filepath    string
+	attenuation float64
+}
+
+// voiceStream reads a decoded sample back with the voice's pan and pitch
+// applied, so every voice can share the cached PCM bytes
+type voiceStream struct {
+	pcm       []byte
+	position  float64
+	pitch     float64
+	leftGain  float64
+	rightGain float64
+}
+
+func newVoiceStream(pcm []byte, pan, pitch float64) *voiceStream {
+	pan = math.Max(-1, math.Min(1, pan))
+	return &voiceStream{
+		pcm:       pcm,
+		pitch:     pitch,
+		leftGain:  math.Min(1, 1-pan),
+		rightGain: math.Min(1, 1+pan),
+	}
+}
+
+func (v *voiceStream) sample(frame, channel int) float64 {
+	i := (frame * bytesPerFrame) + (channel * 2)
+	return float64(int16(binary.LittleEndian.Uint16(v.pcm[i:])))
+}
+
+func (v *voiceStream) Read(buf []byte) (int, error) {
+	frames := len(v.pcm) / bytesPerFrame
+	n := 0
+
+	for n+bytesPerFrame <= len(buf) {
+		frame := int(v.position)
+		if frame >= frames-1 {
+			break
+		}
+
+		// blend neighbouring frames so pitched voices don't crackle
+		t := v.position - float64(frame)
+		left := (v.sample(frame, 0)*(1-t) + v.sample(frame+1, 0)*t) * v.leftGain
+		right := (v.sample(frame, 1)*(1-t) + v.sample(frame+1, 1)*t) * v.rightGain
+
+		binary.LittleEndian.PutUint16(buf[n:], uint16(int16(left)))
+		binary.LittleEndian.PutUint16(buf[n+2:], uint16(int16(right)))
+
+		n += bytesPerFrame
+		v.position += v.pitch
+	}
+
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// PlaySFX plays a sound centred on the screen
+func (a *Audio) PlaySFX(filepath string) {
+	a.PlaySFXAt(filepath, 0)
+}
+
+// PlaySFXAt plays a sound panned between the left (-1) and right (1) of the
+// screen. Every call gets its own voice so the same sound can overlap itself.
+func (a *Audio) PlaySFXAt(filepath string, pan float64) {
+	pcm, ok := a.samples[filepath]
+	if !ok {
+		pcm = a.loadSample(filepath)
+	}
+
+	pitch := 1.0
+	if last, ok := a.lastPlayed[filepath]; ok && a.time-last < repeatWindow {
+		pitch += (rand.Float64()*2 - 1) * repeatPitchVar
+	}
+	a.lastPlayed[filepath] = a.time
+
+	// the oldest voice makes way once the limit is reached
+	if len(a.voices) >= maxVoices {
+		a.voices[0].player.Close()
+		a.voices = a.voices[1:]
+	}
+
+	p, err := a.audioContext.NewPlayer(newVoiceStream(pcm, pan, pitch))
+	if err != nil {
+		return
+	}
+	v := &voice{player: p, filepath: filepath, attenuation: 1 - (math.Abs(pan) * panAttenuation)}
+	p.SetVolume(a.sfx.gain() * v.attenuation)
+	p.Play()
+
+	a.voices = append(a.voices, v)
+}
+
+func (a *Audio) StopSFX() {
+	for _, v := range a.voices {
+		v.player.Close()
+	}
+	a.voices = []*voice{}
+}
+
+// releases voices that have finished playing
+func (a *Audio) updateVoices() {
+	playing := a.voices[:0]
+	for _, v := range a.voices {
+		if v.player.IsPlaying() {
+			playing = append(playing, v)
+		} else {
+			v.player.Close()
+		}
+	}
+	a.voices = playing
+}
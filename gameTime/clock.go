@@ -0,0 +1,129 @@
+package gameTime
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	minTimeScale = 0
+	maxTimeScale = 10
+	// ticks this close to a step are counted as exactly one, so timer
+	// jitter doesn't turn a steady TPS into ticks of zero or two steps
+	snapTolerance = 0.002
+	// a tick after a long stall only catches up this many seconds, rather
+	// than running steps until the game falls further behind
+	maxTickTime = 0.25
+)
+
+// Clock drives the game with a fixed timestep. Every tick adds the real
+// time since the last one, scaled by the time scale, to an accumulator and
+// the game then runs as many whole steps of 1/TPS seconds as it holds. A
+// faster time scale runs more steps rather than longer ones, so physics
+// behaves the same at any speed. What is left over says how far the game
+// is towards the next step, for smoothing movement when drawing.
+type Clock struct {
+	step        float64
+	timeScale   float64
+	gameTime    float64
+	accumulator float64
+	paused      bool
+	lastTick    time.Time
+}
+
+func (c *Clock) Init() {
+	c.step = 1 / float64(ebiten.MaxTPS())
+	c.timeScale = 1
+	c.gameTime = 0
+	c.accumulator = 0
+	c.paused = false
+	c.lastTick = time.Now()
+}
+
+// Tick is called once at the start of every ebiten Update, before the
+// steps for it are taken with Step
+func (c *Clock) Tick() {
+	now := time.Now()
+	elapsed := now.Sub(c.lastTick).Seconds()
+	c.lastTick = now
+
+	// time spent paused never reaches the game
+	if c.paused {
+		return
+	}
+
+	if elapsed > maxTickTime {
+		elapsed = maxTickTime
+	}
+	if elapsed > c.step-snapTolerance && elapsed < c.step+snapTolerance {
+		elapsed = c.step
+	}
+	c.accumulator += elapsed * c.timeScale
+}
+
+// Step takes one step out of the accumulator, false once there isn't a
+// whole step left in it this tick
+func (c *Clock) Step() bool {
+	if c.paused || c.accumulator < c.step {
+		return false
+	}
+	c.accumulator -= c.step
+	c.gameTime += c.step
+	return true
+}
+
+// Returns the length of one step, for things like scene transitions that
+// shouldn't slow down or stop with the game
+func (c *Clock) GetStep() float64 {
+	return c.step
+}
+
+// Returns the length of a step of game time, zero while paused
+func (c *Clock) GetDeltaTime() float64 {
+	if c.paused {
+		return 0
+	}
+	return c.step
+}
+
+// Returns the seconds of game time that have passed since Init
+func (c *Clock) GetTime() float64 {
+	return c.gameTime
+}
+
+// Returns how far the accumulator is towards the next step, from 0 to 1,
+// for drawing movement part way between the last two steps
+func (c *Clock) GetAlpha() float64 {
+	alpha := c.accumulator / c.step
+	if alpha > 1 {
+		return 1
+	}
+	return alpha
+}
+
+func (c *Clock) GetTimeScale() float64 {
+	return c.timeScale
+}
+
+func (c *Clock) SetTimeScale(scale float64) {
+	if scale < minTimeScale {
+		scale = minTimeScale
+	}
+	if scale > maxTimeScale {
+		scale = maxTimeScale
+	}
+	c.timeScale = scale
+}
+
+func (c *Clock) Pause() {
+	c.paused = true
+}
+
+func (c *Clock) Resume() {
+	c.paused = false
+}
+
+func (c *Clock) IsPaused() bool {
+	return c.paused
+}
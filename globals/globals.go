@@ -2,18 +2,23 @@ package globals
 
 import (
 	_ "embed"
+	"fmt"
 
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/mharv/scrapyard-charter/basics"
 	"github.com/mharv/scrapyard-charter/data"
 	"github.com/mharv/scrapyard-charter/gameAudio"
+	"github.com/mharv/scrapyard-charter/settings"
 )
 
 const (
 	ScreenWidth  = 1366
 	ScreenHeight = 768
-	Debug        = false
 )
 
+// Debug turns on the debug overlays, it follows the debug setting
+var Debug = false
+
 var playerData = &data.PlayerData{InitialOverworldPosition: basics.Vector2f{
 	X: ScreenWidth / 2,
 	Y: ScreenHeight / 2,
@@ -21,9 +26,43 @@ var playerData = &data.PlayerData{InitialOverworldPosition: basics.Vector2f{
 
 var audioPlayer = &gameAudio.Audio{}
 
+var gameSettings = &settings.Settings{}
+
 func InitAudioPlayer() {
 	audioPlayer.Init()
-	audioPlayer.LoadFiles("audio")
+	ApplyAudioSettings()
+}
+
+func InitSettings() {
+	gameSettings.Init()
+	if err := gameSettings.Load(); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// ApplySettings pushes every setting onto the window, audio and debug state
+func ApplySettings() {
+	ebiten.SetWindowSize(int(ScreenWidth*gameSettings.WindowScale), int(ScreenHeight*gameSettings.WindowScale))
+	ebiten.SetFullscreen(gameSettings.Fullscreen)
+	if gameSettings.VSync {
+		ebiten.SetFPSMode(ebiten.FPSModeVsyncOn)
+	} else {
+		ebiten.SetFPSMode(ebiten.FPSModeVsyncOffMaximum)
+	}
+	Debug = gameSettings.DebugOverlays
+	ApplyAudioSettings()
+}
+
+// ApplyAudioSettings pushes the saved volumes onto the audio buses
+func ApplyAudioSettings() {
+	audioPlayer.SetMusicVolume(gameSettings.MusicVolume)
+	audioPlayer.SetMusicMuted(gameSettings.MusicMuted)
+	audioPlayer.SetSFXVolume(gameSettings.SFXVolume)
+	audioPlayer.SetSFXMuted(gameSettings.SFXMuted)
+}
+
+func GetSettings() *settings.Settings {
+	return gameSettings
 }
 
 func GetAudioPlayer() *gameAudio.Audio {
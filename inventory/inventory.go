@@ -1,16 +1,25 @@
 package inventory
 
+import (
+	"sort"
+
+	"github.com/mharv/scrapyard-charter/catalog"
+)
+
 type Inventory struct {
-	keyItems          []KeyItem
-	items             []Item
-	materials         map[string]int
-	NewRodAcquired    bool
-	NewReelAcquired   bool
-	NewLineAcquired   bool
-	NewMagnetAcquired bool
-	NewBootsAcquired  bool
-	NewElecAcquired   bool
-	NewRepAcquired    bool
+	keyItems       []KeyItem
+	itemStacks     map[string]*ItemStack
+	itemListeners  map[int]ItemListener
+	nextListenerID int
+	itemWeight     float64
+	salvageQueue   []salvageJob
+	queuedWeight   float64
+	salvageLog     []SalvageResult
+	materials      map[string]int
+	// slots with a key item the player hasn't seen yet
+	newKeyItems map[catalog.Slot]bool
+	// charges of each consumable by name
+	consumables map[string]int
 }
 
 func (i *Inventory) InitMaterials() {
@@ -47,7 +56,72 @@ func (i *Inventory) GetKeyItems() []KeyItem {
 	return i.keyItems
 }
 
-func (i *Inventory) GetKeyItemsByType(typeOfKeyItemRequired string) []KeyItem {
+// SetKeyItemLevel changes the level of a held key item and returns the
+// updated copy
+func (i *Inventory) SetKeyItemLevel(name string, level int) (KeyItem, bool) {
+	for j := range i.keyItems {
+		if i.keyItems[j].name == name {
+			i.keyItems[j].SetLevel(level)
+			return i.keyItems[j], true
+		}
+	}
+	return KeyItem{}, false
+}
+
+// SetKeyItemDurability changes the durability of a held key item and
+// returns the updated copy
+func (i *Inventory) SetKeyItemDurability(name string, durability float64) (KeyItem, bool) {
+	for j := range i.keyItems {
+		if i.keyItems[j].name == name {
+			i.keyItems[j].SetDurability(durability)
+			return i.keyItems[j], true
+		}
+	}
+	return KeyItem{}, false
+}
+
+// SetNewKeyItem flags the slot as having a key item the player hasn't
+// looked at yet
+func (i *Inventory) SetNewKeyItem(slot catalog.Slot) {
+	if i.newKeyItems == nil {
+		i.newKeyItems = make(map[catalog.Slot]bool)
+	}
+	i.newKeyItems[slot] = true
+}
+
+func (i *Inventory) HasNewKeyItem(slot catalog.Slot) bool {
+	return i.newKeyItems[slot]
+}
+
+func (i *Inventory) ClearNewKeyItems() {
+	i.newKeyItems = nil
+}
+
+func (i *Inventory) AddConsumable(name string, charges int) {
+	if i.consumables == nil {
+		i.consumables = make(map[string]int)
+	}
+	i.consumables[name] += charges
+}
+
+// UseConsumable spends a charge of the consumable, false if there are none
+func (i *Inventory) UseConsumable(name string) bool {
+	if i.consumables[name] <= 0 {
+		return false
+	}
+	i.consumables[name]--
+	return true
+}
+
+func (i *Inventory) GetConsumableCount(name string) int {
+	return i.consumables[name]
+}
+
+func (i *Inventory) GetConsumables() map[string]int {
+	return i.consumables
+}
+
+func (i *Inventory) GetKeyItemsByType(typeOfKeyItemRequired catalog.Slot) []KeyItem {
 	keyItemsByType := []KeyItem{}
 	for _, v := range i.keyItems {
 		if v.keyItemType == typeOfKeyItemRequired {
@@ -59,7 +133,19 @@ func (i *Inventory) GetKeyItemsByType(typeOfKeyItemRequired string) []KeyItem {
 }
 
 func (i *Inventory) AddItem(item Item) {
-	i.items = append(i.items, item)
+	if i.itemStacks == nil {
+		i.itemStacks = make(map[string]*ItemStack)
+	}
+
+	stack, ok := i.itemStacks[item.name]
+	if !ok {
+		stack = &ItemStack{name: item.name}
+		i.itemStacks[item.name] = stack
+	}
+	stack.items = append(stack.items, item)
+	i.itemWeight += item.GetWeight()
+
+	i.notifyItemListeners(item.name, len(stack.items))
 }
 
 func (i *Inventory) AddMaterial(name string, amount int) {
@@ -71,8 +157,44 @@ func (i *Inventory) RemoveMaterial(name string, amount int) {
 	i.materials[name] -= amount
 }
 
+// GetItems returns every item held, grouped by name in alphabetical order
 func (i *Inventory) GetItems() []Item {
-	return i.items
+	items := []Item{}
+	for _, name := range i.GetItemNames() {
+		items = append(items, i.itemStacks[name].items...)
+	}
+	return items
+}
+
+// GetItemNames returns the name of each stack held in alphabetical order
+func (i *Inventory) GetItemNames() []string {
+	names := []string{}
+	for k := range i.itemStacks {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (i *Inventory) GetItemCount(name string) int {
+	if stack, ok := i.itemStacks[name]; ok {
+		return len(stack.items)
+	}
+	return 0
+}
+
+// GetItemWeight is the combined weight of every item held, including the
+// ones waiting to be salvaged
+func (i *Inventory) GetItemWeight() float64 {
+	return i.itemWeight + i.queuedWeight
+}
+
+func (i *Inventory) GetTotalItemCount() int {
+	total := 0
+	for _, v := range i.itemStacks {
+		total += len(v.items)
+	}
+	return total
 }
 
 func (i *Inventory) GetMaterials() map[string]int {
@@ -82,44 +204,62 @@ func (i *Inventory) GetMaterials() map[string]int {
 func (i *Inventory) RemoveAllItemWithName(name string) []map[string]RawMaterial {
 	var salvagedMaterials []map[string]RawMaterial
 
-	for index, element := range i.items {
-		if element.name == name {
-			salvagedMaterials = append(salvagedMaterials, i.items[index].GetMaterials())
-			i.items = append(i.items[:index], i.items[index+1:]...)
-
-			otherMaterials := i.RemoveAllItemWithName(name)
-			salvagedMaterials = append(salvagedMaterials, otherMaterials...)
+	stack, ok := i.itemStacks[name]
+	if !ok {
+		return salvagedMaterials
+	}
 
-			return salvagedMaterials
-		}
+	for _, v := range stack.items {
+		salvagedMaterials = append(salvagedMaterials, v.GetMaterials())
+		i.itemWeight -= v.GetWeight()
 	}
+	delete(i.itemStacks, name)
+
+	i.notifyItemListeners(name, 0)
 	return salvagedMaterials
 }
 
+// RemoveOneItemWithName takes the most recently added item off the stack
 func (i *Inventory) RemoveOneItemWithName(name string) map[string]RawMaterial {
 	var salvagedMaterials map[string]RawMaterial
-	for index, element := range i.items {
-		if element.name == name {
-			salvagedMaterials = i.items[index].GetMaterials()
-			i.items = append(i.items[:index], i.items[index+1:]...)
-			return salvagedMaterials
-		}
+
+	if item, ok := i.TakeItem(name); ok {
+		salvagedMaterials = item.GetMaterials()
 	}
 	return salvagedMaterials
 }
 
-func (i *Inventory) SalvageOneItem(name string) {
-	salvagedMaterials := i.RemoveOneItemWithName(name)
-	for k, v := range salvagedMaterials {
-		i.AddMaterial(k, v.GetAmount())
+// PeekItem returns the item TakeItem would take without removing it
+func (i *Inventory) PeekItem(name string) (Item, bool) {
+	stack, ok := i.itemStacks[name]
+	if !ok {
+		return Item{}, false
 	}
+	return stack.items[len(stack.items)-1], true
 }
 
-func (i *Inventory) SalvageAllItems(name string) {
-	salvagedMaterials := i.RemoveAllItemWithName(name)
-	for _, material := range salvagedMaterials {
-		for k, v := range material {
-			i.AddMaterial(k, v.GetAmount())
-		}
+// TakeItem removes the most recently added item with the name and returns
+// it whole, so it can be moved somewhere else with its rolled amounts
+func (i *Inventory) TakeItem(name string) (Item, bool) {
+	stack, ok := i.itemStacks[name]
+	if !ok {
+		return Item{}, false
+	}
+
+	last := len(stack.items) - 1
+	item := stack.items[last]
+	i.itemWeight -= item.GetWeight()
+	stack.items = stack.items[:last]
+	if len(stack.items) == 0 {
+		delete(i.itemStacks, name)
 	}
+
+	i.notifyItemListeners(name, len(stack.items))
+	return item, true
+}
+
+// MoveMaterial moves every unit of a material into another inventory
+func (i *Inventory) MoveMaterial(to *Inventory, name string) {
+	to.AddMaterial(name, i.materials[name])
+	i.materials[name] = 0
 }
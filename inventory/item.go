@@ -49,12 +49,33 @@ func (i *Item) GetMaterials() map[string]RawMaterial {
 	return i.rawMaterials
 }
 
+func (i *Item) GetTotalMaterialAmount() int {
+	total := 0
+	for _, v := range i.rawMaterials {
+		total += v.GetAmount()
+	}
+	return total
+}
+
+// GetWeight is how much of the players carry capacity the item takes up,
+// one for every unit of material it salvages into
+func (i *Item) GetWeight() float64 {
+	return float64(i.GetTotalMaterialAmount())
+}
+
 func (i *Item) AddRawMaterial(name string, min, max int) {
 	r := RawMaterial{}
 	r.SetMinAndMax(min, max)
 	i.rawMaterials[name] = r
 }
 
+// Used when restoring an item that has already had its amounts rolled
+func (i *Item) SetRawMaterialAmount(name string, amount int) {
+	r := i.rawMaterials[name]
+	r.amount = amount
+	i.rawMaterials[name] = r
+}
+
 func (i *Item) AddModifier(name string, amount float64) {
 	if val, ok := i.modifiers[name]; ok {
 		val += amount
@@ -0,0 +1,45 @@
+package inventory
+
+// ItemStack holds every caught item with the same name, each keeps the
+// material amounts it rolled when it was caught
+type ItemStack struct {
+	name  string
+	items []Item
+}
+
+// ItemListener is called whenever the number of items with a name
+// changes, count is zero once the last one is gone
+type ItemListener func(name string, count int)
+
+func (s *ItemStack) GetName() string {
+	return s.name
+}
+
+func (s *ItemStack) GetCount() int {
+	return len(s.items)
+}
+
+func (s *ItemStack) GetItems() []Item {
+	return s.items
+}
+
+// Subscribe registers a listener for item changes, the returned id is
+// passed to Unsubscribe when it is no longer wanted
+func (i *Inventory) Subscribe(listener ItemListener) int {
+	if i.itemListeners == nil {
+		i.itemListeners = make(map[int]ItemListener)
+	}
+	i.nextListenerID++
+	i.itemListeners[i.nextListenerID] = listener
+	return i.nextListenerID
+}
+
+func (i *Inventory) Unsubscribe(id int) {
+	delete(i.itemListeners, id)
+}
+
+func (i *Inventory) notifyItemListeners(name string, count int) {
+	for _, v := range i.itemListeners {
+		v(name, count)
+	}
+}
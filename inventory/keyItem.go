@@ -1,16 +1,25 @@
 package inventory
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mharv/scrapyard-charter/catalog"
+)
 
 type KeyItem struct {
 	name string
 	// keyItemTypeIndex          int
-	keyItemType               string
-	modifiers                 KeyItemModifiers
+	keyItemType               catalog.Slot
+	modifiers                 []catalog.Modifier
+	level                     int
+	durability                float64
 	materialsRequiredForCraft map[string]float64
 	keyItemImage              *ebiten.Image
 }
 
+// KeyItemModifiers is the value of one of a key items modifiers at its
+// current level
 type KeyItemModifiers struct {
 	ModifierName  string
 	ModifierValue float64
@@ -24,14 +33,63 @@ func (k *KeyItem) GetKeyItemImage() *ebiten.Image {
 	return k.keyItemImage
 }
 
-func (k *KeyItem) GetKeyItemType() string {
+func (k *KeyItem) GetKeyItemType() catalog.Slot {
 	return k.keyItemType
 }
 
-func (k *KeyItem) GetKeyItemModifiers() KeyItemModifiers {
+// GetKeyItemModifiers returns every modifier worked out for the current level
+func (k *KeyItem) GetKeyItemModifiers() []KeyItemModifiers {
+	modifiers := []KeyItemModifiers{}
+	for _, v := range k.modifiers {
+		modifiers = append(modifiers, KeyItemModifiers{ModifierName: v.Name, ModifierValue: v.At(k.level)})
+	}
+	return modifiers
+}
+
+// GetModifiers returns the modifiers as the recipe has them, at level 1
+func (k *KeyItem) GetModifiers() []catalog.Modifier {
 	return k.modifiers
 }
 
+func (k *KeyItem) GetLevel() int {
+	return k.level
+}
+
+func (k *KeyItem) SetLevel(level int) {
+	if level < 1 {
+		level = 1
+	} else if level > catalog.MaxKeyItemLevel {
+		level = catalog.MaxKeyItemLevel
+	}
+	k.level = level
+}
+
+func (k *KeyItem) CanUpgrade() bool {
+	return catalog.CanUpgrade(k.modifiers, k.level)
+}
+
+// GetUpgradeCost is what the next level costs, it goes up with every level
+func (k *KeyItem) GetUpgradeCost() map[string]float64 {
+	return catalog.UpgradeCost(k.materialsRequiredForCraft, k.level)
+}
+
+func (k *KeyItem) GetDurability() float64 {
+	return k.durability
+}
+
+func (k *KeyItem) SetDurability(durability float64) {
+	k.durability = math.Max(0, math.Min(durability, catalog.MaxDurability))
+}
+
+func (k *KeyItem) NeedsRepair() bool {
+	return k.durability < catalog.MaxDurability
+}
+
+// GetRepairCost is what bringing the key item back to full durability costs
+func (k *KeyItem) GetRepairCost() map[string]float64 {
+	return catalog.RepairCost(k.materialsRequiredForCraft, k.durability)
+}
+
 // func (k *KeyItem) GetKeyItemTypeIndex() int {
 // 	return k.keyItemTypeIndex
 // }
@@ -44,10 +102,12 @@ func (k *KeyItem) GetCraftingRecipe() map[string]float64 {
 	return k.materialsRequiredForCraft
 }
 
-func (k *KeyItem) Init(name, keyItemType string, modifiers KeyItemModifiers, materialsRequiredForCraft map[string]float64, keyItemImage *ebiten.Image) {
+func (k *KeyItem) Init(name string, keyItemType catalog.Slot, modifiers []catalog.Modifier, materialsRequiredForCraft map[string]float64, keyItemImage *ebiten.Image) {
 	k.name = name
 	k.keyItemType = keyItemType
 	k.modifiers = modifiers
+	k.level = 1
+	k.durability = catalog.MaxDurability
 	k.materialsRequiredForCraft = materialsRequiredForCraft
 	k.keyItemImage = keyItemImage
 }
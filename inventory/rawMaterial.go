@@ -3,6 +3,8 @@ package inventory
 import (
 	"math/rand"
 	"time"
+
+	"github.com/mharv/scrapyard-charter/catalog"
 )
 
 type RawMaterial struct {
@@ -13,7 +15,7 @@ func (r *RawMaterial) RandomiseAmount() {
 	src := rand.NewSource(time.Now().UnixNano())
 	rnd := rand.New(src)
 
-	r.amount = rnd.Intn(r.max-r.min) + r.min
+	r.amount = catalog.RollAmount(rnd, r.min, r.max)
 }
 
 func (r *RawMaterial) SetMinAndMax(min, max int) {
@@ -0,0 +1,138 @@
+package inventory
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/mharv/scrapyard-charter/catalog"
+)
+
+// SalvageTool is what the player salvages with, set from the equipped
+// tool and whether they are at the workbench
+type SalvageTool struct {
+	// Yield is the share of each material recovered
+	Yield float64
+	// Precision tools can recover byproducts that need one
+	Precision bool
+	// Speed multiplies how fast the queue is worked through
+	Speed float64
+}
+
+// SalvageResult is an entry in the salvage log, what one item gave
+type SalvageResult struct {
+	Name       string
+	Materials  map[string]int
+	Byproducts map[string]int
+}
+
+// salvage rolls share one source, seeding one per item would give items
+// finishing in the same tick the same byproducts
+var salvageRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+type salvageJob struct {
+	item     Item
+	timeLeft float64
+}
+
+const (
+	salvageBaseTime      = 0.5
+	salvageTimePerWeight = 0.02
+	salvageLogLength     = 5
+)
+
+// queueSalvage puts an item at the back of the salvage queue, it keeps
+// weighing on the bag until it has been salvaged
+func (i *Inventory) queueSalvage(item Item) {
+	i.salvageQueue = append(i.salvageQueue, salvageJob{
+		item:     item,
+		timeLeft: salvageBaseTime + item.GetWeight()*salvageTimePerWeight,
+	})
+	i.queuedWeight += item.GetWeight()
+}
+
+func (i *Inventory) SalvageOneItem(name string) {
+	if item, ok := i.TakeItem(name); ok {
+		i.queueSalvage(item)
+	}
+}
+
+func (i *Inventory) SalvageAllItems(name string) {
+	for {
+		item, ok := i.TakeItem(name)
+		if !ok {
+			return
+		}
+		i.queueSalvage(item)
+	}
+}
+
+// UpdateSalvage works through the salvage queue, time left over from one
+// item carries on to the next
+func (i *Inventory) UpdateSalvage(deltaTime float64, tool SalvageTool) {
+	progress := deltaTime * tool.Speed
+
+	for len(i.salvageQueue) > 0 && progress > 0 {
+		job := &i.salvageQueue[0]
+		if job.timeLeft > progress {
+			job.timeLeft -= progress
+			return
+		}
+
+		progress -= job.timeLeft
+		i.finishSalvage(job.item, tool)
+		i.salvageQueue = i.salvageQueue[1:]
+	}
+}
+
+func (i *Inventory) finishSalvage(item Item, tool SalvageTool) {
+	result := SalvageResult{
+		Name:       item.GetName(),
+		Materials:  make(map[string]int),
+		Byproducts: make(map[string]int),
+	}
+
+	for k, v := range item.GetMaterials() {
+		result.Materials[k] = catalog.SalvageAmount(v.GetAmount(), tool.Yield)
+		i.AddMaterial(k, result.Materials[k])
+	}
+
+	if junk, ok := catalog.FindJunk(item.GetName()); ok {
+		result.Byproducts = junk.RollByproducts(salvageRand, tool.Precision)
+		for k, v := range result.Byproducts {
+			i.AddMaterial(k, v)
+		}
+	}
+
+	i.queuedWeight -= item.GetWeight()
+
+	i.salvageLog = append([]SalvageResult{result}, i.salvageLog...)
+	if len(i.salvageLog) > salvageLogLength {
+		i.salvageLog = i.salvageLog[:salvageLogLength]
+	}
+}
+
+// GetSalvageQueue returns the items waiting to be salvaged, first in line
+// first
+func (i *Inventory) GetSalvageQueue() []Item {
+	items := []Item{}
+	for _, v := range i.salvageQueue {
+		items = append(items, v.item)
+	}
+	return items
+}
+
+// GetSalvageProgress is how far through the item at the front of the
+// queue salvaging is, from 0 to 1
+func (i *Inventory) GetSalvageProgress() float64 {
+	if len(i.salvageQueue) == 0 {
+		return 0
+	}
+	job := i.salvageQueue[0]
+	total := salvageBaseTime + job.item.GetWeight()*salvageTimePerWeight
+	return 1 - job.timeLeft/total
+}
+
+// GetSalvageLog returns what the last few salvaged items gave, newest first
+func (i *Inventory) GetSalvageLog() []SalvageResult {
+	return i.salvageLog
+}
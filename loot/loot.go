@@ -0,0 +1,81 @@
+package loot
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// Table picks entries at random in proportion to their weights. Weights
+// that are negative, NaN or infinite count as zero so a bad entry can never
+// be picked or skew the rest.
+type Table struct {
+	weights    []float64
+	cumulative []float64
+	total      float64
+}
+
+var ErrNoWeight = errors.New("loot table has no entries with weight")
+
+func (t *Table) Init() {
+	t.weights = []float64{}
+	t.cumulative = []float64{}
+	t.total = 0
+}
+
+// Add appends an entry, its index is the number of entries added before it
+func (t *Table) Add(weight float64) {
+	if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+		weight = 0
+	}
+	t.total += weight
+	t.weights = append(t.weights, weight)
+	t.cumulative = append(t.cumulative, t.total)
+}
+
+func (t *Table) Len() int {
+	return len(t.weights)
+}
+
+func (t *Table) GetTotalWeight() float64 {
+	return t.total
+}
+
+func (t *Table) GetWeight(index int) float64 {
+	return t.weights[index]
+}
+
+// GetProbability is the chance of Pick returning index, 0 when the table
+// has no weight at all
+func (t *Table) GetProbability(index int) float64 {
+	if t.total <= 0 {
+		return 0
+	}
+	return t.weights[index] / t.total
+}
+
+// Pick returns the index of a random entry, or ErrNoWeight if every entry
+// has a weight of zero
+func (t *Table) Pick(rnd *rand.Rand) (int, error) {
+	if t.total <= 0 {
+		return -1, ErrNoWeight
+	}
+
+	num := rnd.Float64() * t.total
+
+	for i, v := range t.cumulative {
+		// zero weight entries share their cumulative value with the entry
+		// before them so they can never be the first one above num
+		if num < v {
+			return i, nil
+		}
+	}
+
+	// num can only reach here through float rounding at the very top
+	for i := len(t.weights) - 1; i >= 0; i-- {
+		if t.weights[i] > 0 {
+			return i, nil
+		}
+	}
+	return -1, ErrNoWeight
+}
@@ -0,0 +1,107 @@
+package loot
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestAddClampsBadWeights(t *testing.T) {
+	tests := []struct {
+		name   string
+		weight float64
+		want   float64
+	}{
+		{"positive", 2.5, 2.5},
+		{"zero", 0, 0},
+		{"negative", -1, 0},
+		{"NaN", math.NaN(), 0},
+		{"positive infinity", math.Inf(1), 0},
+		{"negative infinity", math.Inf(-1), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := Table{}
+			table.Init()
+			table.Add(1)
+			table.Add(tt.weight)
+
+			if got := table.GetWeight(1); got != tt.want {
+				t.Errorf("weight = %v, want %v", got, tt.want)
+			}
+			if got := table.GetTotalWeight(); got != 1+tt.want {
+				t.Errorf("total = %v, want %v", got, 1+tt.want)
+			}
+		})
+	}
+}
+
+func TestPickNeverReturnsZeroWeight(t *testing.T) {
+	table := Table{}
+	table.Init()
+	table.Add(0)
+	table.Add(1)
+	table.Add(math.NaN())
+	table.Add(0)
+	table.Add(1)
+	table.Add(-3)
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		picked, err := table.Pick(rnd)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if table.GetWeight(picked) <= 0 {
+			t.Fatalf("picked entry %d with no weight", picked)
+		}
+	}
+}
+
+func TestNoWeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []float64
+	}{
+		{"empty", nil},
+		{"all zero", []float64{0, 0}},
+		{"all bad", []float64{math.NaN(), -1, math.Inf(1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := Table{}
+			table.Init()
+			for _, v := range tt.weights {
+				table.Add(v)
+			}
+
+			picked, err := table.Pick(rand.New(rand.NewSource(1)))
+			if !errors.Is(err, ErrNoWeight) || picked != -1 {
+				t.Errorf("Pick = %d, %v, want -1, ErrNoWeight", picked, err)
+			}
+			for i := range tt.weights {
+				if got := table.GetProbability(i); got != 0 {
+					t.Errorf("probability of %d = %v, want 0", i, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGetProbability(t *testing.T) {
+	table := Table{}
+	table.Init()
+	table.Add(1)
+	table.Add(3)
+	table.Add(0)
+
+	want := []float64{0.25, 0.75, 0}
+	for i, v := range want {
+		if got := table.GetProbability(i); got != v {
+			t.Errorf("probability of %d = %v, want %v", i, got, v)
+		}
+	}
+}
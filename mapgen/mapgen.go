@@ -11,20 +11,38 @@ import (
 const w = globals.ScreenWidth
 const h = globals.ScreenHeight
 
+const medianFilterPasses = 10
+
+// Progress is called between generation stages with the stage name and how
+// far through the whole map generation it is, from 0 to 1
+type Progress func(stage string, amount float64)
+
 func GenerateMap(l_open, r_open, u_open, d_open bool) [w][h]float64 {
+	return GenerateMapWithProgress(l_open, r_open, u_open, d_open, func(string, float64) {})
+}
+
+func GenerateMapWithProgress(l_open, r_open, u_open, d_open bool, progress Progress) [w][h]float64 {
 
 	// generate fall off map and return terrain map
 	var fallOffMap [w][h]float64
 	var terrain [w][h]float64
 
+	progress("Shaping the shoreline", 0)
 	fallOffMap = createSquareFallOffMap(fallOffMap)
 
 	// generate fall off map with sides open
 	fallOffMap = openFallOffMapSide(fallOffMap, l_open, r_open, u_open, d_open)
 
+	progress("Dumping scrap", 0.1)
 	terrain = applyPerlinNoise(terrain, fallOffMap)
-	// smooth out values using filter to reduce noise
-	terrain = applyMedianFilterNTime(terrain, 10)
+
+	// smooth out values using filter to reduce noise, the filter is the
+	// slowest stage so it reports after every pass
+	for i := 0; i < medianFilterPasses; i++ {
+		progress("Settling the piles", 0.3+(0.7*float64(i)/medianFilterPasses))
+		terrain = applyMedianFilterNTime(terrain, 1)
+	}
+	progress("Settling the piles", 1)
 
 	return terrain
 }
@@ -6,7 +6,6 @@ import (
 	"io"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/audio"
 	"github.com/hajimehoshi/ebiten/v2/audio/mp3"
 	"github.com/tinne26/etxt"
 )
@@ -54,34 +53,39 @@ func LoadFileAsFont(Filename string) *etxt.FontLibrary {
 	return fontLib
 }
 
-func LoadFolderAsAudio(Folder string, context *audio.Context) map[string]*audio.Player {
+// Opens a file for streaming, the mp3 is decoded as it plays rather than
+// up front so long music tracks don't sit in memory
+func LoadFileAsStream(Filename string) *mp3.Stream {
+	file, err := AudioFS.Open(Filename)
+	if err != nil {
+		panic(err)
+	}
 
-	streams := map[string]*audio.Player{}
-	//var files embed.FS
-	folder, err := AudioFS.ReadDir(Folder)
+	d, err := mp3.DecodeWithSampleRate(SampleRate, file)
 	if err != nil {
 		panic(err)
 	}
 
-	for _, v := range folder {
-		name := Folder + "/" + v.Name()
+	return d
+}
 
-		file, err := AudioFS.Open(name)
-		if err != nil {
-			panic(err)
-		}
+// Decodes a whole file into 16 bit stereo PCM at SampleRate, for short
+// sounds that are played many times at once
+func LoadFileAsPCM(Filename string) []byte {
+	file, err := AudioFS.Open(Filename)
+	if err != nil {
+		panic(err)
+	}
 
-		d, err := mp3.DecodeWithSampleRate(SampleRate, file)
-		if err != nil {
-			panic(err)
-		}
+	d, err := mp3.DecodeWithSampleRate(SampleRate, file)
+	if err != nil {
+		panic(err)
+	}
 
-		p, err := context.NewPlayer(d)
-		if err != nil {
-			panic("Cannot create player for: " + name)
-		}
-		streams[name] = p
+	bs, err := io.ReadAll(d)
+	if err != nil {
+		panic(err)
 	}
 
-	return streams
+	return bs
 }
@@ -0,0 +1,280 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/crafting"
+	"github.com/mharv/scrapyard-charter/globals"
+	"github.com/mharv/scrapyard-charter/resources"
+	"github.com/tinne26/etxt"
+)
+
+// consoleCommand runs with the words typed after the command name and
+// returns the line to print back
+type consoleCommand struct {
+	usage string
+	run   func(state *GameState, args []string) string
+}
+
+var consoleCommands = map[string]consoleCommand{
+	"give": {
+		usage: "give <material> <amount>",
+		run: func(state *GameState, args []string) string {
+			if len(args) != 2 {
+				return ""
+			}
+			amount, err := strconv.Atoi(args[1])
+			if err != nil {
+				return ""
+			}
+			for _, v := range globals.MaterialNamesList {
+				if strings.EqualFold(v, args[0]) {
+					// crafting pays from the stash, so that is where it goes
+					globals.GetPlayerData().GetStash().AddMaterial(v, amount)
+					return fmt.Sprintf("Gave %d %s to the stash", amount, v)
+				}
+			}
+			return "Unknown material " + args[0]
+		},
+	},
+	"craft": {
+		usage: "craft <key item name>",
+		run: func(state *GameState, args []string) string {
+			if len(args) < 1 {
+				return ""
+			}
+			// key item names have spaces in them
+			name := strings.ToUpper(strings.Join(args, " "))
+			cb := &crafting.CraftingBench{}
+			cb.Init()
+			keyItem, ok := cb.GetKeyItemByName(name)
+			if !ok {
+				return "Unknown key item " + name
+			}
+			for _, v := range globals.GetPlayerData().GetInventory().GetKeyItems() {
+				if v.GetKeyItemName() == keyItem.GetKeyItemName() {
+					return "Already have " + keyItem.GetKeyItemName()
+				}
+			}
+			cb.AcquireKeyItem(keyItem)
+			return "Crafted " + keyItem.GetKeyItemName()
+		},
+	},
+	"seed": {
+		usage: "seed <number>",
+		run: func(state *GameState, args []string) string {
+			if len(args) != 1 {
+				return ""
+			}
+			seed, err := strconv.Atoi(args[0])
+			if err != nil {
+				return ""
+			}
+			globals.GetPlayerData().SetWorldSeed(seed)
+			if _, ok := state.SceneManager.scenes[0].(*OverworldScene); ok {
+				state.SceneManager.GoTo(&OverworldScene{}, 0)
+				return fmt.Sprintf("Regenerating with seed %d", seed)
+			}
+			return fmt.Sprintf("Seed set to %d", seed)
+		},
+	},
+	"teleport": {
+		usage: "teleport <x> <y>",
+		run: func(state *GameState, args []string) string {
+			if len(args) != 2 {
+				return ""
+			}
+			x, errX := strconv.ParseFloat(args[0], 64)
+			y, errY := strconv.ParseFloat(args[1], 64)
+			if errX != nil || errY != nil {
+				return ""
+			}
+			o, ok := state.SceneManager.scenes[0].(*OverworldScene)
+			if !ok {
+				return "Can only teleport in the overworld"
+			}
+			o.Teleport(basics.Vector2f{X: x, Y: y})
+			return fmt.Sprintf("Teleported to %.0f, %.0f", x, y)
+		},
+	},
+	"timer": {
+		usage: "timer <seconds>",
+		run: func(state *GameState, args []string) string {
+			if len(args) != 1 {
+				return ""
+			}
+			seconds, err := strconv.ParseFloat(args[0], 64)
+			if err != nil {
+				return ""
+			}
+			s, ok := state.SceneManager.scenes[0].(*ScavengeScene)
+			if !ok {
+				return "Can only set the timer while scavenging"
+			}
+			s.SetTimer(seconds)
+			return fmt.Sprintf("Timer set to %.1f", seconds)
+		},
+	},
+	"timescale": {
+		usage: "timescale <scale>",
+		run: func(state *GameState, args []string) string {
+			if len(args) != 1 {
+				return ""
+			}
+			scale, err := strconv.ParseFloat(args[0], 64)
+			if err != nil {
+				return ""
+			}
+			state.Clock.SetTimeScale(scale)
+			return fmt.Sprintf("Time scale set to %.2f", state.Clock.GetTimeScale())
+		},
+	},
+}
+
+// ConsoleScene takes typed debug commands over whatever scene is open
+type ConsoleScene struct {
+	input        string
+	history      []string
+	historyIndex int
+	log          []string
+	runes        []rune
+	submit       bool
+	close        bool
+	txtRenderer  *etxt.Renderer
+}
+
+const (
+	consoleHeight      = 300
+	consoleLineHeight  = 24
+	consoleMaxLogLines = 10
+	consoleTextX       = 16
+	consoleInputY      = consoleHeight - 36
+	consoleToggleKey   = ebiten.KeyGraveAccent
+	consoleFontSize    = 22
+)
+
+func (c *ConsoleScene) Init() {
+	c.input = ""
+	c.historyIndex = len(c.history)
+	c.close = false
+	if len(c.log) == 0 {
+		c.log = append(c.log, "Type help for a list of commands")
+	}
+
+	fontLib := resources.LoadFileAsFont("fonts/Rajdhani-Regular.ttf")
+
+	c.txtRenderer = etxt.NewStdRenderer()
+	glyphsCache := etxt.NewDefaultCache(10 * 1024 * 1024) // 10MB
+	c.txtRenderer.SetCacheHandler(glyphsCache.NewHandler())
+	c.txtRenderer.SetFont(fontLib.GetFont("Rajdhani Regular"))
+	c.txtRenderer.SetAlign(etxt.Top, etxt.Left)
+	c.txtRenderer.SetSizePx(consoleFontSize)
+}
+
+func (c *ConsoleScene) DrawsBelow() bool {
+	return true
+}
+
+func (c *ConsoleScene) UpdatesBelow() bool {
+	return false
+}
+
+func (c *ConsoleScene) CapturesInput() bool {
+	return true
+}
+
+func (c *ConsoleScene) ReadInput() {
+	c.runes = ebiten.AppendInputChars(c.runes[:0])
+	for _, v := range c.runes {
+		// the toggle key types a character as well as closing
+		if v != '`' {
+			c.input += string(v)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(c.input) > 0 {
+		typed := []rune(c.input)
+		c.input = string(typed[:len(typed)-1])
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) && c.historyIndex > 0 {
+		c.historyIndex--
+		c.input = c.history[c.historyIndex]
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) && c.historyIndex < len(c.history) {
+		c.historyIndex++
+		c.input = ""
+		if c.historyIndex < len(c.history) {
+			c.input = c.history[c.historyIndex]
+		}
+	}
+
+	c.submit = inpututil.IsKeyJustPressed(ebiten.KeyEnter)
+	c.close = inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(consoleToggleKey)
+}
+
+func (c *ConsoleScene) Update(state *GameState, deltaTime float64) error {
+	if c.close {
+		state.SceneManager.Pop()
+		return nil
+	}
+
+	if c.submit && strings.TrimSpace(c.input) != "" {
+		c.history = append(c.history, c.input)
+		c.historyIndex = len(c.history)
+		c.log = append(c.log, "> "+c.input)
+		c.log = append(c.log, c.run(state, strings.Fields(c.input)))
+		c.input = ""
+
+		if len(c.log) > consoleMaxLogLines {
+			c.log = c.log[len(c.log)-consoleMaxLogLines:]
+		}
+	}
+
+	return nil
+}
+
+func (c *ConsoleScene) run(state *GameState, words []string) string {
+	name := strings.ToLower(words[0])
+
+	if name == "help" {
+		names := []string{}
+		for k := range consoleCommands {
+			names = append(names, consoleCommands[k].usage)
+		}
+		sort.Strings(names)
+		return strings.Join(names, ", ")
+	}
+
+	command, ok := consoleCommands[name]
+	if !ok {
+		return "Unknown command " + name
+	}
+
+	if result := command.run(state, words[1:]); result != "" {
+		return result
+	}
+	return "Usage: " + command.usage
+}
+
+func (c *ConsoleScene) Draw(screen *ebiten.Image) {
+	ebitenutil.DrawRect(screen, 0, 0, globals.ScreenWidth, consoleHeight, color.RGBA{40, 38, 36, 220})
+	ebitenutil.DrawRect(screen, 0, consoleHeight, globals.ScreenWidth, 2, color.RGBA{67, 52, 85, 255})
+
+	c.txtRenderer.SetTarget(screen)
+	c.txtRenderer.SetColor(color.RGBA{154, 154, 151, 255})
+	for i, v := range c.log {
+		c.txtRenderer.Draw(v, consoleTextX, consoleInputY-((len(c.log)-i)*consoleLineHeight)-8)
+	}
+
+	c.txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+	c.txtRenderer.Draw("> "+c.input+"_", consoleTextX, consoleInputY)
+}
@@ -0,0 +1,74 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/solarlune/resolv"
+)
+
+// DebugInspectable can be implemented by scenes with physics so the debug
+// overlay can count and outline what is in them
+type DebugInspectable interface {
+	GetEntityCount() int
+	GetPhysSpace() *resolv.Space
+}
+
+// outline colours in order of priority, objects take the colour of the
+// first tag they have
+var debugTagColours = []struct {
+	tag    string
+	colour color.RGBA
+}{
+	{"player", color.RGBA{0, 80, 255, 255}},
+	{"magnet", color.RGBA{255, 80, 0, 255}},
+	{"magneticField", color.RGBA{255, 160, 0, 128}},
+	{"target", color.RGBA{255, 0, 0, 255}},
+	{"junk", color.RGBA{255, 220, 0, 255}},
+	{"home", color.RGBA{255, 0, 255, 255}},
+	{"craft", color.RGBA{0, 255, 255, 255}},
+	{"scrap", color.RGBA{154, 79, 80, 255}},
+	{"land", color.RGBA{93, 104, 114, 128}},
+	{"solid", color.RGBA{255, 255, 255, 255}},
+}
+
+func drawOutline(screen *ebiten.Image, x, y, w, h float64, clr color.Color) {
+	ebitenutil.DrawLine(screen, x, y, x+w, y, clr)
+	ebitenutil.DrawLine(screen, x+w, y, x+w, y+h, clr)
+	ebitenutil.DrawLine(screen, x+w, y+h, x, y+h, clr)
+	ebitenutil.DrawLine(screen, x, y+h, x, y, clr)
+}
+
+func (s *SceneManager) drawDebugOverlay(screen *ebiten.Image) {
+	text := fmt.Sprintf("FPS: %.1f  TPS: %.1f/%d\nTime scale: %.2f", ebiten.CurrentFPS(), ebiten.CurrentTPS(), ebiten.MaxTPS(), s.Clock.GetTimeScale())
+	text += fmt.Sprintf("\nScenes: %d", len(s.scenes))
+
+	if len(s.scenes) > 0 {
+		if inspectable, ok := s.scenes[0].(DebugInspectable); ok {
+			space := inspectable.GetPhysSpace()
+			counts := make([]int, len(debugTagColours))
+
+			for _, o := range space.Objects() {
+				for i, v := range debugTagColours {
+					if o.HasTags(v.tag) {
+						drawOutline(screen, o.X, o.Y, o.W, o.H, v.colour)
+						counts[i]++
+						break
+					}
+				}
+			}
+
+			text += fmt.Sprintf("\nEntities: %d  Physics objects: %d", inspectable.GetEntityCount(), len(space.Objects()))
+			for i, v := range debugTagColours {
+				if counts[i] > 0 {
+					text += fmt.Sprintf("\n  %s: %d", v.tag, counts[i])
+				}
+			}
+		}
+	}
+
+	text += "\n[F3] hide overlay  [`] console"
+	ebitenutil.DebugPrint(screen, text)
+}
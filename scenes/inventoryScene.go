@@ -0,0 +1,49 @@
+package scenes
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mharv/scrapyard-charter/globals"
+	"github.com/mharv/scrapyard-charter/ui"
+)
+
+// InventoryScene shows the inventory over the scene that pushed it,
+// the ui is owned by that scene so it isn't reloaded every time it opens
+type InventoryScene struct {
+	ui *ui.Ui
+}
+
+func (i *InventoryScene) Init() {
+	i.ui.Open()
+}
+
+func (i *InventoryScene) ReadInput() {
+	i.ui.ReadInput()
+}
+
+func (i *InventoryScene) Update(state *GameState, deltaTime float64) error {
+	if !i.ui.IsOpen() {
+		state.SceneManager.Pop()
+		return nil
+	}
+
+	// the scene below isn't updated so salvaging carries on here
+	globals.GetPlayerData().UpdateSalvage(deltaTime)
+
+	return i.ui.Update(deltaTime)
+}
+
+func (i *InventoryScene) Draw(screen *ebiten.Image) {
+	i.ui.Draw(screen)
+}
+
+func (i *InventoryScene) DrawsBelow() bool {
+	return true
+}
+
+func (i *InventoryScene) UpdatesBelow() bool {
+	return false
+}
+
+func (i *InventoryScene) CapturesInput() bool {
+	return true
+}
@@ -0,0 +1,116 @@
+package scenes
+
+import (
+	"image/color"
+	"runtime"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/mharv/scrapyard-charter/resources"
+	"github.com/tinne26/etxt"
+)
+
+// LoadProgress reports the current loading stage and how far through the
+// whole load it is, from 0 to 1
+type LoadProgress func(stage string, amount float64)
+
+// Loader can be implemented by scenes with slow setup. Load runs in a
+// background goroutine before Init, so it must not create ebiten images or
+// touch anything the running scenes use. Init is still called on the main
+// thread once Load returns.
+type Loader interface {
+	Scene
+	Load(progress LoadProgress)
+}
+
+// LoadingScene is shown by the scene manager while a Loader loads, then
+// hands over to it with the transition it was given
+type LoadingScene struct {
+	target      Loader
+	transition  Transition
+	fadeTime    float64
+	lock        sync.Mutex
+	stage       string
+	amount      float64
+	done        bool
+	txtRenderer *etxt.Renderer
+}
+
+const (
+	loadingFadeTime                  = 0.5
+	loadingBarW, loadingBarH         = 600, 24
+	loadingBarX, loadingBarY         = 383, 420
+	loadingHeadingX, loadingHeadingY = 383, 300
+	loadingStageOffsetY              = 40
+	loadingBarBorder                 = 4
+)
+
+func (l *LoadingScene) Init() {
+	l.stage = ""
+	l.amount = 0
+	l.done = false
+
+	fontLib := resources.LoadFileAsFont("fonts/Rajdhani-Regular.ttf")
+
+	l.txtRenderer = etxt.NewStdRenderer()
+	glyphsCache := etxt.NewDefaultCache(10 * 1024 * 1024) // 10MB
+	l.txtRenderer.SetCacheHandler(glyphsCache.NewHandler())
+	l.txtRenderer.SetFont(fontLib.GetFont("Rajdhani Regular"))
+	l.txtRenderer.SetAlign(etxt.Top, etxt.Left)
+	l.txtRenderer.SetSizePx(24)
+
+	go func() {
+		l.target.Load(l.report)
+
+		l.lock.Lock()
+		l.done = true
+		l.lock.Unlock()
+	}()
+}
+
+func (l *LoadingScene) report(stage string, amount float64) {
+	l.lock.Lock()
+	l.stage = stage
+	l.amount = amount
+	l.lock.Unlock()
+
+	// browsers run every goroutine on one thread, give the game loop a turn
+	runtime.Gosched()
+}
+
+func (l *LoadingScene) ReadInput() {}
+
+func (l *LoadingScene) Update(state *GameState, deltaTime float64) error {
+	l.lock.Lock()
+	done := l.done
+	l.lock.Unlock()
+
+	if done {
+		state.SceneManager.goTo(l.target, l.transition, l.fadeTime)
+	}
+
+	return nil
+}
+
+func (l *LoadingScene) Draw(screen *ebiten.Image) {
+	l.lock.Lock()
+	stage := l.stage
+	amount := l.amount
+	l.lock.Unlock()
+
+	screen.Fill(color.RGBA{40, 38, 36, 255})
+
+	l.txtRenderer.SetTarget(screen)
+	l.txtRenderer.SetSizePx(80)
+	l.txtRenderer.SetColor(color.RGBA{157, 159, 127, 255})
+	l.txtRenderer.Draw("Loading", loadingHeadingX, loadingHeadingY)
+
+	ebitenutil.DrawRect(screen, loadingBarX-loadingBarBorder, loadingBarY-loadingBarBorder, loadingBarW+(loadingBarBorder*2), loadingBarH+(loadingBarBorder*2), color.RGBA{67, 52, 85, 255})
+	ebitenutil.DrawRect(screen, loadingBarX, loadingBarY, loadingBarW, loadingBarH, color.RGBA{110, 105, 98, 255})
+	ebitenutil.DrawRect(screen, loadingBarX, loadingBarY, loadingBarW*amount, loadingBarH, color.RGBA{197, 204, 184, 255})
+
+	l.txtRenderer.SetSizePx(30)
+	l.txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+	l.txtRenderer.Draw(stage, loadingBarX, loadingBarY+loadingStageOffsetY)
+}
@@ -1,6 +1,7 @@
 package scenes
 
 import (
+	"fmt"
 	"image"
 	"image/color"
 	"math"
@@ -11,12 +12,15 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/catalog"
 	"github.com/mharv/scrapyard-charter/entities"
 	"github.com/mharv/scrapyard-charter/globals"
 	"github.com/mharv/scrapyard-charter/mapgen"
 	"github.com/mharv/scrapyard-charter/resources"
+	"github.com/mharv/scrapyard-charter/settings"
 	"github.com/mharv/scrapyard-charter/ui"
 	"github.com/solarlune/resolv"
+	"github.com/tinne26/etxt"
 )
 
 type Tile struct {
@@ -41,6 +45,8 @@ func (t *TileMap) GetTile(X, Y int) float64 {
 type OverworldScene struct {
 	entityManager                   entities.EntityManager
 	menuBtn, castBtn, castAvailable bool
+	inventoryBtn, stashBtn          bool
+	statsBtn                        bool
 	physSpace                       *resolv.Space
 	scrapspritesheet                *ebiten.Image
 	overlayspritesheet              *ebiten.Image
@@ -50,23 +56,26 @@ type OverworldScene struct {
 	spawnZone                       basics.FloatRect
 	player                          entities.OverworldPlayerObject
 	castDistance                    float64
+	castTarget                      basics.Vector2f
 	ui                              ui.Ui
 	terrain                         TileMap
+	txtRenderer                     *etxt.Renderer
 }
 
 const (
-	cellSize      = 8
-	tilesetcellsX = 4
-	tilesetcellsY = 4
+	cellSize          = 8
+	tilesetcellsX     = 4
+	tilesetcellsY     = 4
+	bagFullFontSize   = 22
+	bagFullTextOffset = 24
+	baseHintX         = 20
+	baseHintY         = globals.ScreenHeight - 40
 )
 
-func (o *OverworldScene) Init() {
-	globals.GetAudioPlayer().StopAllAudio()
-
+// Load generates the terrain off the main thread, images and entities are
+// created afterwards in Init
+func (o *OverworldScene) Load(progress LoadProgress) {
 	o.physSpace = resolv.NewSpace(globals.ScreenWidth, globals.ScreenHeight, cellSize, cellSize)
-	o.entityManager.Init()
-	o.ui = ui.Ui{}
-	o.ui.Init()
 	// object array
 	geometry := []*resolv.Object{}
 
@@ -74,7 +83,11 @@ func (o *OverworldScene) Init() {
 	var terrain [globals.ScreenWidth][globals.ScreenHeight]float64
 
 	// create a terrain map L, R, U, D - if true, side is open
-	terrain = mapgen.GenerateMap(false, false, false, false)
+	// map generation is most of the load so it fills the first 80% of the bar
+	terrain = mapgen.GenerateMapWithProgress(false, false, false, false, func(stage string, amount float64) {
+		progress(stage, amount*0.8)
+	})
+	progress("Laying out the yard", 0.8)
 
 	// we create 32 x 32 pixel blocks
 	tempCellSize := cellSize * 4
@@ -143,14 +156,36 @@ func (o *OverworldScene) Init() {
 
 	o.terrain = *newTerrain
 
+	// add generated objects to scene space
+	o.physSpace.Add(geometry...)
+	progress("Laying out the yard", 1)
+}
+
+func (o *OverworldScene) Unload() {
+	o.ui.Close()
+}
+
+func (o *OverworldScene) Init() {
+	globals.GetAudioPlayer().StopSFX()
+
+	o.entityManager.Init()
+	o.ui = ui.Ui{}
+	o.ui.Init()
+
 	o.scrapspritesheet = LoadImage("images/junkTileset.png")
 	o.overlayspritesheet = LoadImage("images/junktileset2.png")
 	o.landspritesheet = LoadImage("images/dirttileset.png")
 	o.cursorNo = LoadImage("images/owCursorNo.png")
 	o.cursorYes = LoadImage("images/owCursorYes.png")
 
-	// add generated objects to scene space
-	o.physSpace.Add(geometry...)
+	fontLib := resources.LoadFileAsFont("fonts/Rajdhani-Regular.ttf")
+
+	o.txtRenderer = etxt.NewStdRenderer()
+	glyphsCache := etxt.NewDefaultCache(10 * 1024 * 1024) // 10MB
+	o.txtRenderer.SetCacheHandler(glyphsCache.NewHandler())
+	o.txtRenderer.SetFont(fontLib.GetFont("Rajdhani Regular"))
+	o.txtRenderer.SetAlign(etxt.Top, etxt.Left)
+	o.txtRenderer.SetSizePx(bagFullFontSize)
 
 	o.spawnZone.Width = globals.ScreenWidth
 	o.spawnZone.Height = globals.ScreenHeight
@@ -179,7 +214,15 @@ func (o *OverworldScene) Init() {
 
 func (o *OverworldScene) ReadInput() {
 	o.entityManager.ReadInput()
-	o.ui.ReadInput()
+
+	if inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionInventory)) {
+		o.inventoryBtn = true
+	} else {
+		o.inventoryBtn = false
+	}
+
+	o.stashBtn = inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionStash))
+	o.statsBtn = inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionStats))
 
 	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
 		o.menuBtn = true
@@ -187,8 +230,8 @@ func (o *OverworldScene) ReadInput() {
 		o.menuBtn = false
 	}
 
-	if (inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) ||
-		inpututil.IsKeyJustPressed(ebiten.Key(ebiten.KeyE))) && !o.ui.IsOpen() {
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) ||
+		inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionCast)) {
 		o.castBtn = true
 	} else {
 		o.castBtn = false
@@ -196,20 +239,42 @@ func (o *OverworldScene) ReadInput() {
 }
 
 func (o *OverworldScene) Update(state *GameState, deltaTime float64) error {
-	globals.GetAudioPlayer().PlayFile("audio/overworld.mp3")
+	globals.GetAudioPlayer().PlayMusic("audio/overworld.mp3")
+
+	if o.menuBtn {
+		state.SceneManager.Pause()
+		return nil
+	}
+
+	if o.inventoryBtn {
+		i := &InventoryScene{ui: &o.ui}
+		state.SceneManager.Push(i)
+		return nil
+	}
+
+	if o.stashBtn && globals.GetPlayerData().CheckIfInCraftZone() {
+		state.SceneManager.Push(&StashScene{})
+		return nil
+	}
+
+	if o.statsBtn {
+		state.SceneManager.Push(&StatsScene{})
+		return nil
+	}
 
 	o.entityManager.Update(deltaTime)
-	o.ui.Update(deltaTime)
+	globals.GetPlayerData().UpdateSalvage(deltaTime)
+	globals.GetPlayerData().GetStats().Update(deltaTime)
 
-	if o.castAvailable && o.castBtn && o.castDistance < o.player.CastDistanceLimit && !o.ui.IsOpen() {
+	if o.castAvailable && o.castBtn && o.castDistance < o.player.CastDistanceLimit {
 		s := &ScavengeScene{distanceOfOverworldCast: o.castDistance}
 		globals.GetPlayerData().SetPlayerPosition(basics.Vector2f{X: o.player.GetPhysObj().X, Y: o.player.GetPhysObj().Y})
-		state.SceneManager.GoTo(s, transitionTime)
+		state.SceneManager.GoToWithTransition(s, &DiveTransition{Target: o.castTarget}, transitionTime)
 	}
 
-	winCondition, err := globals.GetPlayerData().GetEquippedItem("Magnet")
+	winCondition, err := globals.GetPlayerData().GetEquippedItem(catalog.MagnetSlot)
 	if err == nil {
-		if winCondition.GetKeyItemName() == "GOLDENMAGNET" {
+		if winCondition.GetKeyItemName() == catalog.WinningKeyItem {
 			w := &WinScene{}
 			state.SceneManager.GoTo(w, transitionTime)
 		}
@@ -225,12 +290,16 @@ func (o *OverworldScene) Draw(screen *ebiten.Image) {
 	mx, my := o.physSpace.WorldToSpace(float64(mouseX), float64(mouseY))
 	cx, cy := o.player.GetCellPosition()
 	o.castDistance = math.Sqrt(math.Pow((float64(mx)-float64(cx))*8, 2) + math.Pow((float64(my)-float64(cy))*8, 2))
-	drawColor := color.RGBA{255, 0, 0, 255}
+	o.castTarget = basics.Vector2f{X: float64(mx) * cellSize, Y: float64(my) * cellSize}
+	palette := globals.GetSettings().GetPalette()
+	drawColor := palette.Bad
 
 	cellAtMouse := o.physSpace.Cell(mx, my)
+	overScrap := cellAtMouse != nil && cellAtMouse.ContainsTags("scrap")
 	if cellAtMouse != nil {
-		if cellAtMouse.ContainsTags("scrap") && o.castDistance < o.player.CastDistanceLimit {
-			drawColor = color.RGBA{0, 255, 0, 255}
+		// a full bag has to be salvaged before diving again
+		if overScrap && o.castDistance < o.player.CastDistanceLimit && !globals.GetPlayerData().IsBagFull() {
+			drawColor = palette.Good
 			o.castAvailable = true
 		} else {
 			o.castAvailable = false
@@ -335,18 +404,45 @@ func (o *OverworldScene) Draw(screen *ebiten.Image) {
 
 	o.entityManager.Draw(screen)
 	o.DrawOverlay(screen)
-	o.ui.Draw(screen)
 
 	if !o.ui.IsOpen() {
 		mop := &ebiten.DrawImageOptions{}
 		mop.GeoM.Translate(float64(mx)*cellSize, float64(my)*cellSize)
 		if o.castAvailable {
 			mop.GeoM.Translate(-float64(o.cursorYes.Bounds().Dx())/2, -float64(o.cursorYes.Bounds().Dy())/2)
+			if globals.GetSettings().ColourBlind {
+				tintCursor(mop, palette.Good)
+			}
 			screen.DrawImage(o.cursorYes, mop)
 		} else {
 			mop.GeoM.Translate(-float64(o.cursorNo.Bounds().Dx())/2, -float64(o.cursorNo.Bounds().Dy())/2)
+			if globals.GetSettings().ColourBlind {
+				tintCursor(mop, palette.Bad)
+			}
 			screen.DrawImage(o.cursorNo, mop)
 		}
+
+		if overScrap && globals.GetPlayerData().IsBagFull() {
+			o.txtRenderer.SetTarget(screen)
+			o.txtRenderer.SetColor(color.RGBA{154, 79, 80, 255})
+			o.txtRenderer.Draw(
+				fmt.Sprintf("Bag full, salvage junk from your inventory [%s]", globals.GetSettings().GetKey(settings.ActionInventory).String()),
+				mouseX+bagFullTextOffset,
+				mouseY,
+			)
+		}
+	}
+
+	if globals.GetPlayerData().CheckIfInCraftZone() {
+		o.txtRenderer.SetTarget(screen)
+		o.txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+		o.txtRenderer.Draw(
+			fmt.Sprintf("Home base  [%s] stash  [%s] craft",
+				globals.GetSettings().GetKey(settings.ActionStash).String(),
+				globals.GetSettings().GetKey(settings.ActionInventory).String()),
+			baseHintX,
+			baseHintY,
+		)
 	}
 
 	// draw the mouse to character distance check line
@@ -379,6 +475,30 @@ func (o *OverworldScene) DrawOverlay(screen *ebiten.Image) {
 	}
 }
 
+func (o *OverworldScene) Interpolate(alpha float64) {
+	o.entityManager.Interpolate(alpha)
+}
+
+func (o *OverworldScene) GetEntityCount() int {
+	return o.entityManager.GetEntityCount()
+}
+
+func (o *OverworldScene) GetPhysSpace() *resolv.Space {
+	return o.physSpace
+}
+
+func (o *OverworldScene) Teleport(position basics.Vector2f) {
+	o.player.SetPosition(position)
+	globals.GetPlayerData().SetPlayerPosition(position)
+}
+
+// the cursor sprites are red and green, so they are redrawn in a single
+// palette colour keeping only their shape
+func tintCursor(op *ebiten.DrawImageOptions, clr color.RGBA) {
+	op.ColorM.Scale(0, 0, 0, 1)
+	op.ColorM.Translate(float64(clr.R)/0xff, float64(clr.G)/0xff, float64(clr.B)/0xff, 0)
+}
+
 func LoadImage(filepath string) *ebiten.Image {
 	return resources.LoadFileAsImage(filepath)
 }
@@ -0,0 +1,176 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/globals"
+	"github.com/mharv/scrapyard-charter/resources"
+	"github.com/tinne26/etxt"
+)
+
+type PauseScene struct {
+	options       []string
+	optionButtons []basics.FloatRectUI
+	selected      int
+	up, down      bool
+	confirm, back bool
+	mouseClick    bool
+	cursorPos     basics.Vector2f
+	txtRenderer   *etxt.Renderer
+	statusText    string
+	statusCounter float64
+}
+
+const (
+	pauseOptionResume   = "Resume"
+	pauseOptionSave     = "Save"
+	pauseOptionTitle    = "Return to title"
+	pauseOptionQuit     = "Quit"
+	pauseOptionSettings = "Settings"
+
+	pausePanelW, pausePanelH     = 420, 480
+	pausePanelX, pausePanelY     = (globals.ScreenWidth - pausePanelW) / 2, (globals.ScreenHeight - pausePanelH) / 2
+	pauseHeadingX, pauseHeadingY = pausePanelX + 30, pausePanelY + 10
+	pauseOptionX, pauseOptionY   = pausePanelX + 40, pausePanelY + 110
+	pauseOptionH                 = 56
+	pauseStatusY                 = pausePanelY + pausePanelH - 50
+	pauseStatusDuration          = 2
+)
+
+func (p *PauseScene) Init() {
+	p.selected = 0
+	p.statusText = ""
+	p.statusCounter = 0
+
+	p.optionButtons = []basics.FloatRectUI{}
+	for i, v := range p.options {
+		p.optionButtons = append(p.optionButtons, basics.FloatRectUI{
+			Name:   v,
+			X:      pauseOptionX,
+			Y:      float64(pauseOptionY + (pauseOptionH * i)),
+			Width:  pausePanelW - 80,
+			Height: pauseOptionH,
+		})
+	}
+
+	fontLib := resources.LoadFileAsFont("fonts/Rajdhani-Regular.ttf")
+
+	p.txtRenderer = etxt.NewStdRenderer()
+	glyphsCache := etxt.NewDefaultCache(10 * 1024 * 1024) // 10MB
+	p.txtRenderer.SetCacheHandler(glyphsCache.NewHandler())
+	p.txtRenderer.SetFont(fontLib.GetFont("Rajdhani Regular"))
+	p.txtRenderer.SetAlign(etxt.Top, etxt.Left)
+}
+
+func (p *PauseScene) DrawsBelow() bool {
+	return true
+}
+
+func (p *PauseScene) UpdatesBelow() bool {
+	return false
+}
+
+func (p *PauseScene) CapturesInput() bool {
+	return true
+}
+
+func (p *PauseScene) ReadInput() {
+	x, y := ebiten.CursorPosition()
+	p.cursorPos.X = float64(x)
+	p.cursorPos.Y = float64(y)
+
+	p.up = inpututil.IsKeyJustPressed(ebiten.KeyW) || inpututil.IsKeyJustPressed(ebiten.KeyArrowUp)
+	p.down = inpututil.IsKeyJustPressed(ebiten.KeyS) || inpututil.IsKeyJustPressed(ebiten.KeyArrowDown)
+	p.confirm = inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace)
+	p.back = inpututil.IsKeyJustPressed(ebiten.KeyEscape)
+	p.mouseClick = inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+}
+
+func (p *PauseScene) Update(state *GameState, deltaTime float64) error {
+	// deltaTime is zero while paused, the menu runs on real time
+	if p.statusCounter > 0 {
+		p.statusCounter -= state.Clock.GetStep()
+	}
+
+	if p.back {
+		state.SceneManager.Resume()
+		return nil
+	}
+
+	if p.up {
+		p.selected = (p.selected + len(p.options) - 1) % len(p.options)
+	}
+	if p.down {
+		p.selected = (p.selected + 1) % len(p.options)
+	}
+
+	for i, v := range p.optionButtons {
+		if v.IsHoveredOver(p.cursorPos) {
+			p.selected = i
+			if p.mouseClick {
+				p.confirm = true
+			}
+		}
+	}
+
+	if !p.confirm {
+		return nil
+	}
+
+	switch p.options[p.selected] {
+	case pauseOptionResume:
+		state.SceneManager.Resume()
+	case pauseOptionSave:
+		if err := globals.GetPlayerData().Save(); err != nil {
+			fmt.Println(err)
+			p.statusText = "Could not save the game"
+		} else {
+			p.statusText = "Game saved"
+		}
+		p.statusCounter = pauseStatusDuration
+	case pauseOptionSettings:
+		state.SceneManager.Push(&SettingsScene{})
+	case pauseOptionTitle:
+		state.SceneManager.Resume()
+		t := &TitleScene{}
+		state.SceneManager.GoToWithTransition(t, &FadeToBlackTransition{}, transitionTime)
+	case pauseOptionQuit:
+		os.Exit(0)
+	}
+
+	return nil
+}
+
+func (p *PauseScene) Draw(screen *ebiten.Image) {
+	ebitenutil.DrawRect(screen, 0, 0, globals.ScreenWidth, globals.ScreenHeight, color.RGBA{0, 0, 0, 160})
+	ebitenutil.DrawRect(screen, pausePanelX, pausePanelY, pausePanelW, pausePanelH, color.RGBA{67, 52, 85, 255})
+	ebitenutil.DrawRect(screen, pausePanelX+2, pausePanelY+2, pausePanelW-4, pausePanelH-4, color.RGBA{154, 154, 151, 255})
+
+	p.txtRenderer.SetTarget(screen)
+	p.txtRenderer.SetSizePx(70)
+	p.txtRenderer.SetColor(color.RGBA{110, 105, 98, 255})
+	p.txtRenderer.Draw("PAUSED", pauseHeadingX, pauseHeadingY)
+
+	p.txtRenderer.SetSizePx(40)
+	for i, v := range p.options {
+		if i == p.selected {
+			ebitenutil.DrawRect(screen, p.optionButtons[i].X, p.optionButtons[i].Y, p.optionButtons[i].Width, p.optionButtons[i].Height, color.RGBA{111, 103, 118, 255})
+			p.txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+		} else {
+			p.txtRenderer.SetColor(color.RGBA{67, 52, 85, 255})
+		}
+		p.txtRenderer.Draw(v, pauseOptionX+10, pauseOptionY+(pauseOptionH*i)+4)
+	}
+
+	if p.statusCounter > 0 {
+		p.txtRenderer.SetSizePx(25)
+		p.txtRenderer.SetColor(color.RGBA{67, 52, 85, 255})
+		p.txtRenderer.Draw(p.statusText, pauseOptionX+10, pauseStatusY)
+	}
+}
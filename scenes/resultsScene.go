@@ -0,0 +1,171 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/mharv/scrapyard-charter/globals"
+	"github.com/mharv/scrapyard-charter/inventory"
+	"github.com/mharv/scrapyard-charter/resources"
+	"github.com/tinne26/etxt"
+)
+
+type ResultsScene struct {
+	catches          []inventory.Item
+	castCount        int
+	continueBtn      bool
+	menuBtn          bool
+	txtRenderer      *etxt.Renderer
+	itemsByCount     map[string]int
+	sortedItemKeys   []string
+	totalValue       int
+	rarestCatch      string
+	previousBest     int
+	previousCatches  int
+	newBestValue     bool
+	newBestCatches   bool
+	instructionsText string
+}
+
+const (
+	resultsHeadingX, resultsHeadingY = 70, 50
+	resultsListX, resultsListY       = 126, 170
+	resultsStatsX, resultsStatsY     = 720, 170
+	resultsLineOffsetY               = 36
+	resultsStatLineOffsetY           = 56
+	resultsMaxListedItems            = 12
+	resultsInstructionsX             = 126
+	resultsInstructionsY             = 680
+)
+
+func (r *ResultsScene) Init() {
+	r.continueBtn = false
+	r.instructionsText = "Press [Enter] to continue"
+
+	r.itemsByCount = make(map[string]int)
+	r.sortedItemKeys = []string{}
+	r.totalValue = 0
+	r.rarestCatch = "-"
+
+	lowestRarity := 0.0
+	for _, v := range r.catches {
+		r.itemsByCount[v.GetName()]++
+		r.totalValue += v.GetTotalMaterialAmount()
+
+		// lower rarity weights are picked less often, so they are the rarer catches
+		if r.rarestCatch == "-" || v.GetRarity() < lowestRarity {
+			lowestRarity = v.GetRarity()
+			r.rarestCatch = v.GetName()
+		}
+	}
+
+	for k := range r.itemsByCount {
+		r.sortedItemKeys = append(r.sortedItemKeys, k)
+	}
+	sort.Strings(r.sortedItemKeys)
+
+	playerData := globals.GetPlayerData()
+	r.previousBest = playerData.GetBestDiveValue()
+	r.previousCatches = playerData.GetBestDiveCatches()
+
+	r.newBestValue = r.totalValue > r.previousBest
+	if r.newBestValue {
+		playerData.SetBestDiveValue(r.totalValue)
+	}
+	r.newBestCatches = len(r.catches) > r.previousCatches
+	if r.newBestCatches {
+		playerData.SetBestDiveCatches(len(r.catches))
+	}
+
+	fontLib := resources.LoadFileAsFont("fonts/Rajdhani-Regular.ttf")
+
+	r.txtRenderer = etxt.NewStdRenderer()
+	glyphsCache := etxt.NewDefaultCache(10 * 1024 * 1024) // 10MB
+	r.txtRenderer.SetCacheHandler(glyphsCache.NewHandler())
+	r.txtRenderer.SetFont(fontLib.GetFont("Rajdhani Regular"))
+	r.txtRenderer.SetAlign(etxt.Top, etxt.Left)
+	r.txtRenderer.SetSizePx(24)
+}
+
+func (r *ResultsScene) ReadInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) ||
+		inpututil.IsKeyJustPressed(ebiten.KeySpace) ||
+		inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		r.continueBtn = true
+	} else {
+		r.continueBtn = false
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		r.menuBtn = true
+	} else {
+		r.menuBtn = false
+	}
+}
+
+func (r *ResultsScene) Update(state *GameState, deltaTime float64) error {
+	globals.GetAudioPlayer().PlayMusic("audio/menu.mp3")
+
+	if r.menuBtn {
+		state.SceneManager.Pause()
+		return nil
+	}
+
+	if r.continueBtn {
+		o := &OverworldScene{}
+		state.SceneManager.GoToWithTransition(o, &IrisTransition{Center: globals.GetPlayerData().GetPlayerPosition()}, transitionTime)
+	}
+
+	return nil
+}
+
+func (r *ResultsScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{40, 38, 36, 255})
+
+	r.txtRenderer.SetTarget(screen)
+	r.txtRenderer.SetSizePx(80)
+	r.txtRenderer.SetColor(color.RGBA{157, 159, 127, 255})
+	r.txtRenderer.Draw("Dive Results", resultsHeadingX, resultsHeadingY)
+
+	r.txtRenderer.SetSizePx(30)
+	r.txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+	if len(r.sortedItemKeys) == 0 {
+		r.txtRenderer.Draw("Nothing caught this dive", resultsListX, resultsListY)
+	}
+	for i, k := range r.sortedItemKeys {
+		if i == resultsMaxListedItems {
+			r.txtRenderer.Draw(fmt.Sprintf("+ %d more", len(r.sortedItemKeys)-resultsMaxListedItems), resultsListX, resultsListY+(resultsLineOffsetY*i))
+			break
+		}
+		r.txtRenderer.Draw(fmt.Sprintf("%d x %s", r.itemsByCount[k], k), resultsListX, resultsListY+(resultsLineOffsetY*i))
+	}
+
+	catchRate := 0.0
+	if r.castCount > 0 {
+		catchRate = float64(len(r.catches)) / float64(r.castCount)
+	}
+
+	r.txtRenderer.SetSizePx(40)
+	r.txtRenderer.Draw(fmt.Sprintf("Items caught: %d", len(r.catches)), resultsStatsX, resultsStatsY)
+	r.txtRenderer.Draw(fmt.Sprintf("Material value: %d", r.totalValue), resultsStatsX, resultsStatsY+resultsStatLineOffsetY)
+	r.txtRenderer.Draw(fmt.Sprintf("Rarest catch: %s", r.rarestCatch), resultsStatsX, resultsStatsY+(resultsStatLineOffsetY*2))
+	r.txtRenderer.Draw(fmt.Sprintf("Catch rate: %.2f per cast (%d casts)", catchRate, r.castCount), resultsStatsX, resultsStatsY+(resultsStatLineOffsetY*3))
+
+	r.txtRenderer.SetColor(color.RGBA{157, 159, 127, 255})
+	if r.newBestValue {
+		r.txtRenderer.Draw(fmt.Sprintf("New best value! (previous %d)", r.previousBest), resultsStatsX, resultsStatsY+(resultsStatLineOffsetY*5))
+	} else {
+		r.txtRenderer.Draw(fmt.Sprintf("Best value: %d", r.previousBest), resultsStatsX, resultsStatsY+(resultsStatLineOffsetY*5))
+	}
+	if r.newBestCatches {
+		r.txtRenderer.Draw(fmt.Sprintf("New most caught! (previous %d)", r.previousCatches), resultsStatsX, resultsStatsY+(resultsStatLineOffsetY*6))
+	} else {
+		r.txtRenderer.Draw(fmt.Sprintf("Most caught: %d", r.previousCatches), resultsStatsX, resultsStatsY+(resultsStatLineOffsetY*6))
+	}
+
+	r.txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+	r.txtRenderer.Draw(r.instructionsText, resultsInstructionsX, resultsInstructionsY)
+}
@@ -10,9 +10,11 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/catalog"
 	"github.com/mharv/scrapyard-charter/entities"
 	"github.com/mharv/scrapyard-charter/globals"
 	"github.com/mharv/scrapyard-charter/resources"
+	"github.com/mharv/scrapyard-charter/settings"
 	"github.com/solarlune/resolv"
 	"github.com/tinne26/etxt"
 )
@@ -26,17 +28,24 @@ type ScavengeScene struct {
 	UIPipeSprite            *ebiten.Image
 	physSpace               *resolv.Space
 	menuBtn                 bool
+	hotbar                  int
 	spawnZone               basics.FloatRect
 	distanceOfOverworldCast float64
 	txtRenderer             *etxt.Renderer
 	countdownTimer          float64
-	junkList                []entities.JunkObject
+	junkList                []catalog.Junk
+	magnet                  *entities.MagnetObject
+	timerBonuses            []timerBonus
 	UIPosition              basics.Vector2f
 }
 
+type timerBonus struct {
+	amount  float64
+	counter float64
+}
+
 const (
 	spawnZoneEdgeBorder = 128
-	defaultTimerStart   = 30
 	uiXOffset           = 32
 	uiYOffset           = 32
 	uiGlassXOffset      = 25
@@ -47,6 +56,12 @@ const (
 	iconXOffset         = 184
 	iconYOffset         = 66
 	textRedLimit        = 10.0
+	bonusFontSize       = 30
+	bonusXOffset        = 90
+	bonusYOffset        = 14
+	bonusRiseDistance   = 30
+	bonusDisplayTime    = 1.5
+	carryYOffset        = 8
 )
 
 func (s *ScavengeScene) Init() {
@@ -65,7 +80,8 @@ func (s *ScavengeScene) Init() {
 
 	s.entityManager.Init()
 
-	s.countdownTimer = defaultTimerStart
+	s.countdownTimer = globals.GetPlayerData().GetScavengeTime()
+	s.timerBonuses = []timerBonus{}
 
 	fontLib := resources.LoadFileAsFont("fonts/Rajdhani-Regular.ttf")
 
@@ -109,6 +125,9 @@ func (s *ScavengeScene) Init() {
 		y += s.spawnZone.Y - float64(j.GetPhysObj().Y)
 
 		j.SetPosition(basics.Vector2f{X: x, Y: y})
+		if rnd.Float64() < catalog.BuriedChance(percent) {
+			j.Bury()
+		}
 		s.entityManager.AddEntity(&j)
 		junkLookup[j.GetPhysObj()] = &j
 	}
@@ -122,6 +141,7 @@ func (s *ScavengeScene) Init() {
 	s.physSpace.Add(m.GetFieldPhysObj())
 	s.entityManager.AddEntity(m)
 	p.SetMagnet(m)
+	s.magnet = m
 
 	r := &entities.ScavRodObject{}
 	r.Init("images/rodSection.png")
@@ -140,6 +160,27 @@ func (s *ScavengeScene) Init() {
 	r.Update(0)
 
 	s.menuBtn = false
+	s.hotbar = -1
+}
+
+func (s *ScavengeScene) GetEntityCount() int {
+	return s.entityManager.GetEntityCount()
+}
+
+func (s *ScavengeScene) GetPhysSpace() *resolv.Space {
+	return s.physSpace
+}
+
+func (s *ScavengeScene) SetTimer(seconds float64) {
+	s.countdownTimer = seconds
+}
+
+func (s *ScavengeScene) PreloadAudio() []string {
+	files := []string{}
+	for _, v := range s.junkList {
+		files = append(files, v.AudioFiles...)
+	}
+	return files
 }
 
 func (s *ScavengeScene) ReadInput() {
@@ -150,18 +191,53 @@ func (s *ScavengeScene) ReadInput() {
 	} else {
 		s.menuBtn = false
 	}
+
+	s.hotbar = -1
+	for i, v := range settings.HotbarActions {
+		if inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(v)) {
+			s.hotbar = i
+		}
+	}
+}
+
+// Unload ends the dive however the scene is left, so buffs from consumables
+// don't run on into the overworld or the next dive
+func (s *ScavengeScene) Unload() {
+	s.magnet.EndDive()
 }
 
 func (s *ScavengeScene) Update(state *GameState, deltaTime float64) error {
-	globals.GetAudioPlayer().PlayFile("audio/scavenge.mp3")
+	globals.GetAudioPlayer().PlayMusic("audio/scavenge.mp3")
+
+	if s.hotbar >= 0 && s.hotbar < len(catalog.ConsumableRecipes) {
+		s.magnet.UseConsumable(catalog.ConsumableRecipes[s.hotbar].Name)
+	}
 
 	s.entityManager.Update(deltaTime)
+	globals.GetPlayerData().GetStats().Update(deltaTime)
 
 	s.countdownTimer -= deltaTime
-	if s.countdownTimer <= 0 || s.menuBtn {
+
+	if bonus := s.magnet.TakeTimeBonus(); bonus > 0 {
+		s.countdownTimer += bonus
+		s.timerBonuses = append(s.timerBonuses, timerBonus{amount: bonus, counter: bonusDisplayTime})
+	}
+
+	for i := len(s.timerBonuses) - 1; i >= 0; i-- {
+		s.timerBonuses[i].counter -= deltaTime
+		if s.timerBonuses[i].counter <= 0 {
+			s.timerBonuses = append(s.timerBonuses[:i], s.timerBonuses[i+1:]...)
+		}
+	}
+	if s.menuBtn {
+		state.SceneManager.Pause()
+		return nil
+	}
+
+	if s.countdownTimer <= 0 {
 		s.countdownTimer = 0
-		o := &OverworldScene{}
-		state.SceneManager.GoTo(o, transitionTime)
+		r := &ResultsScene{catches: s.magnet.GetSessionCatches(), castCount: s.magnet.GetCastCount()}
+		state.SceneManager.GoToWithTransition(r, &WipeTransition{}, transitionTime)
 	}
 
 	s.entityManager.RemoveDead(s.physSpace)
@@ -207,189 +283,58 @@ func (s *ScavengeScene) Draw(screen *ebiten.Image) {
 	glassop := &ebiten.DrawImageOptions{}
 	glassop.GeoM.Translate(globals.ScreenWidth-(float64(s.timerUIboxSprite.Bounds().Dx())+uiXOffset)+uiGlassXOffset, uiYOffset+uiGlassYOffset)
 	screen.DrawImage(s.timerUIglassSprite, glassop)
+
+	// time bonuses float up beside the timer and fade out
+	s.txtRenderer.SetSizePx(bonusFontSize)
+	for _, v := range s.timerBonuses {
+		progress := 1 - (v.counter / bonusDisplayTime)
+		s.txtRenderer.SetColor(color.NRGBA{197, 204, 184, uint8(255 * (1 - progress))})
+		s.txtRenderer.Draw(
+			fmt.Sprintf("+%.1fs", v.amount),
+			int(globals.ScreenWidth-(float64(s.timerUIboxSprite.Bounds().Dx())+uiXOffset)-bonusXOffset),
+			int(uiYOffset+bonusYOffset-(progress*bonusRiseDistance)),
+		)
+	}
+
+	// carry weight sits under the timer, going red once junk is being left behind
+	carryText := fmt.Sprintf("Bag %.0f / %.0f", globals.GetPlayerData().GetInventory().GetItemWeight(), globals.GetPlayerData().GetCarryWeight())
+	if s.magnet.IsBagFull() || globals.GetPlayerData().IsBagFull() {
+		carryText = "BAG FULL"
+		s.txtRenderer.SetColor(color.RGBA{154, 79, 80, 255})
+	} else {
+		s.txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+	}
+	s.txtRenderer.Draw(carryText, int(globals.ScreenWidth-(float64(s.timerUIboxSprite.Bounds().Dx())+uiXOffset)), int(uiYOffset+float64(s.timerUIboxSprite.Bounds().Dy())+carryYOffset))
+	s.txtRenderer.SetSizePx(fontSize)
 }
 
 func (s *ScavengeScene) InitJunkList() {
-	cog := &entities.JunkObject{}
-	cog.SetImageFilepath("images/cog.png")
-	cog.InitData()
-	cog.SetItemDataName("Cog")
-	cog.SetItemDataDepthAndRarity(0, 80, 0.1)
-	cog.AddItemDataMaterial("Iron", 5, 10)
-	cog.AddAudioFile("audio/mom1.mp3")
-	cog.AddAudioFile("audio/mom2.mp3")
-	cog.AddAudioFile("audio/mom3.mp3")
-	cog.AddAudioFile("audio/mom4.mp3")
-	cog.AddAudioFile("audio/mom12.mp3")
-	s.junkList = append(s.junkList, *cog)
-
-	ironPipe := &entities.JunkObject{}
-	ironPipe.SetImageFilepath("images/ironpipe.png")
-	ironPipe.InitData()
-	ironPipe.SetItemDataName("Iron Pipe")
-	ironPipe.SetItemDataDepthAndRarity(1, 60, 0.2)
-	ironPipe.AddItemDataMaterial("Iron", 15, 30)
-	ironPipe.AddAudioFile("audio/mop1.mp3")
-	ironPipe.AddAudioFile("audio/mop2.mp3")
-	s.junkList = append(s.junkList, *ironPipe)
-
-	tyre := &entities.JunkObject{}
-	tyre.SetImageFilepath("images/tyre.png")
-	tyre.InitData()
-	tyre.SetItemDataName("Tyre")
-	tyre.SetItemDataDepthAndRarity(2, 50, 0.2)
-	tyre.AddItemDataMaterial("Rubber", 15, 25)
-	tyre.AddItemDataMaterial("Iron", 15, 20)
-	tyre.AddAudioFile("audio/mor1.mp3")
-	tyre.AddAudioFile("audio/mor2.mp3")
-	tyre.AddAudioFile("audio/mor3.mp3")
-	tyre.AddAudioFile("audio/mor4.mp3")
-	s.junkList = append(s.junkList, *tyre)
-
-	steelBikeFrame := &entities.JunkObject{}
-	steelBikeFrame.SetImageFilepath("images/steelbikeframe.png")
-	steelBikeFrame.InitData()
-	steelBikeFrame.SetItemDataName("Steel Bike Frame")
-	steelBikeFrame.SetItemDataDepthAndRarity(3, 40, 0.3)
-	steelBikeFrame.AddItemDataMaterial("Steel", 15, 20)
-	steelBikeFrame.AddItemDataMaterial("Iron", 2, 7)
-	steelBikeFrame.AddItemDataMaterial("Plastic", 0, 1)
-	steelBikeFrame.AddAudioFile("audio/mom5.mp3")
-	steelBikeFrame.AddAudioFile("audio/mom6.mp3")
-	steelBikeFrame.AddAudioFile("audio/mom7.mp3")
-	steelBikeFrame.AddAudioFile("audio/mom8.mp3")
-	steelBikeFrame.AddAudioFile("audio/mom9.mp3")
-	s.junkList = append(s.junkList, *steelBikeFrame)
-
-	monitor := &entities.JunkObject{}
-	monitor.SetImageFilepath("images/monitor.png")
-	monitor.InitData()
-	monitor.SetItemDataName("Monitor")
-	monitor.SetItemDataDepthAndRarity(4, 30, 0.4)
-	monitor.AddItemDataMaterial("Copper", 10, 15)
-	monitor.AddItemDataMaterial("Plastic", 5, 10)
-	monitor.AddItemDataMaterial("Iron", 0, 2)
-	monitor.AddAudioFile("audio/mor5.mp3")
-	monitor.AddAudioFile("audio/mor6.mp3")
-	s.junkList = append(s.junkList, *monitor)
-
-	toaster := &entities.JunkObject{}
-	toaster.SetImageFilepath("images/toaster.png")
-	toaster.InitData()
-	toaster.SetItemDataName("Toaster")
-	toaster.SetItemDataDepthAndRarity(5, 20, 0.5)
-	toaster.AddItemDataMaterial("Nickel", 2, 10)
-	toaster.AddItemDataMaterial("Iron", 1, 5)
-	toaster.AddAudioFile("audio/mom8.mp3")
-	toaster.AddAudioFile("audio/mom9.mp3")
-	toaster.AddAudioFile("audio/mom10.mp3")
-	toaster.AddAudioFile("audio/mom11.mp3")
-	s.junkList = append(s.junkList, *toaster)
-
-	steelPipe := &entities.JunkObject{}
-	steelPipe.SetImageFilepath("images/steelpipe.png")
-	steelPipe.InitData()
-	steelPipe.SetItemDataName("Steel Pipe")
-	steelPipe.SetItemDataDepthAndRarity(6, 18, 0.6)
-	steelPipe.AddItemDataMaterial("Steel", 15, 30)
-	steelPipe.AddAudioFile("audio/mop3.mp3")
-	steelPipe.AddAudioFile("audio/mop4.mp3")
-	s.junkList = append(s.junkList, *steelPipe)
-
-	belt := &entities.JunkObject{}
-	belt.SetImageFilepath("images/belt.png")
-	belt.InitData()
-	belt.SetItemDataName("Belt")
-	belt.SetItemDataDepthAndRarity(7, 15, 0.7)
-	belt.AddItemDataMaterial("Cobalt", 1, 7)
-	belt.AddItemDataMaterial("Rubber", 3, 8)
-	belt.AddItemDataMaterial("Plastic", 0, 2)
-	belt.AddAudioFile("audio/belt1.mp3")
-	belt.AddAudioFile("audio/belt1.mp3")
-	s.junkList = append(s.junkList, *belt)
-
-	copperPipe := &entities.JunkObject{}
-	copperPipe.SetImageFilepath("images/copperpipe.png")
-	copperPipe.InitData()
-	copperPipe.SetItemDataName("Copper Pipe")
-	copperPipe.SetItemDataDepthAndRarity(8, 13, 0.9)
-	copperPipe.AddItemDataMaterial("Copper", 15, 30)
-	copperPipe.AddAudioFile("audio/mop5.mp3")
-	copperPipe.AddAudioFile("audio/mop6.mp3")
-	s.junkList = append(s.junkList, *copperPipe)
-
-	titaniumBikeFrame := &entities.JunkObject{}
-	titaniumBikeFrame.SetImageFilepath("images/titaniumbikeframe.png")
-	titaniumBikeFrame.InitData()
-	titaniumBikeFrame.SetItemDataName("Titanium Bike Frame")
-	titaniumBikeFrame.SetItemDataDepthAndRarity(9, 10, 1.2)
-	titaniumBikeFrame.AddItemDataMaterial("Titanium", 15, 25)
-	titaniumBikeFrame.AddItemDataMaterial("Iron", 0, 2)
-	titaniumBikeFrame.AddItemDataMaterial("Plastic", 2, 4)
-	titaniumBikeFrame.AddAudioFile("audio/mom9.mp3")
-	titaniumBikeFrame.AddAudioFile("audio/mom10.mp3")
-	s.junkList = append(s.junkList, *titaniumBikeFrame)
-
-	titaniumPipe := &entities.JunkObject{}
-	titaniumPipe.SetImageFilepath("images/titaniumpipe.png")
-	titaniumPipe.InitData()
-	titaniumPipe.SetItemDataName("Titanium Pipe")
-	titaniumPipe.SetItemDataDepthAndRarity(10, 9, 1.4)
-	titaniumPipe.AddItemDataMaterial("Titanium", 15, 30)
-	titaniumPipe.AddAudioFile("audio/mop6.mp3")
-	titaniumPipe.AddAudioFile("audio/mop7.mp3")
-	s.junkList = append(s.junkList, *titaniumPipe)
-
-	oldPC := &entities.JunkObject{}
-	oldPC.SetImageFilepath("images/oldpc.png")
-	oldPC.InitData()
-	oldPC.SetItemDataName("Old PC")
-	oldPC.SetItemDataDepthAndRarity(11, 8, 1.8)
-	oldPC.AddItemDataMaterial("Copper", 10, 15)
-	oldPC.AddItemDataMaterial("Plastic", 8, 12)
-	oldPC.AddItemDataMaterial("Steel", 3, 7)
-	oldPC.AddItemDataMaterial("Gold", 1, 3)
-	oldPC.AddAudioFile("audio/mom7.mp3")
-	oldPC.AddAudioFile("audio/mom8.mp3")
-	s.junkList = append(s.junkList, *oldPC)
-
-	battery := &entities.JunkObject{}
-	battery.SetImageFilepath("images/battery.png")
-	battery.InitData()
-	battery.SetItemDataName("Battery")
-	battery.SetItemDataDepthAndRarity(12, 4, 2.5)
-	battery.AddItemDataMaterial("Cobalt", 10, 15)
-	battery.AddItemDataMaterial("Nickel", 10, 15)
-	battery.AddAudioFile("audio/belt1.mp3")
-	battery.AddAudioFile("audio/belt1.mp3")
-	s.junkList = append(s.junkList, *battery)
+	s.junkList = catalog.JunkList
 }
 
+// SelectJunk builds a new junk object of a type chosen by weight, each one
+// gets its own materials so amounts are rolled per piece
 func (s *ScavengeScene) SelectJunk(castDistance float64) entities.JunkObject {
-
-	var junkList []float64
-	var totalRarity float64
-
 	castPercent := (castDistance / globals.GetPlayerData().GetOverworldCastDistance()) * 100
 
-	for _, v := range s.junkList {
-		vRarity := v.GetItemData().GetRarity() * (castPercent * v.GetItemData().GetRarityScale())
-		totalRarity += vRarity
-		junkList = append(junkList, totalRarity)
-	}
-
 	src := rand.NewSource(time.Now().UnixNano())
 	rnd := rand.New(src)
 
-	num := rnd.Intn(int(totalRarity))
-
-	currentChosen := 0
-	for i := range junkList {
-		if num < int(junkList[i]) {
-			currentChosen = i
-			break
-		}
+	chosen := s.junkList[catalog.PickJunk(rnd, s.junkList, castPercent)]
+
+	j := entities.JunkObject{}
+	j.SetImageFilepath(chosen.ImageFilepath)
+	j.InitData()
+	j.SetItemDataName(chosen.Name)
+	j.SetItemDataDepthAndRarity(chosen.Depth, chosen.Rarity, chosen.RarityScale)
+	j.SetReelDifficulty(chosen.ReelDifficulty)
+	j.SetTimeBonus(chosen.TimeBonus)
+	for _, v := range chosen.Materials {
+		j.AddItemDataMaterial(v.Name, v.Min, v.Max)
+	}
+	for _, v := range chosen.AudioFiles {
+		j.AddAudioFile(v)
 	}
 
-	return s.junkList[currentChosen]
+	return j
 }
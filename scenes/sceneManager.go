@@ -2,6 +2,9 @@ package scenes
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/gameTime"
 	"github.com/mharv/scrapyard-charter/globals"
 )
 
@@ -17,70 +20,294 @@ type Scene interface {
 	Draw(screen *ebiten.Image)
 }
 
+// ModalScene can be implemented by scenes pushed on top of the stack to
+// control what the scenes underneath keep doing while it is open. Scenes
+// that don't implement it hide, freeze and take input from everything below.
+type ModalScene interface {
+	Scene
+	DrawsBelow() bool
+	UpdatesBelow() bool
+	CapturesInput() bool
+}
+
+// AudioPreloader can be implemented by scenes to have their sound effects
+// decoded when they start, the sounds are released again once they exit
+type AudioPreloader interface {
+	PreloadAudio() []string
+}
+
+// Unloader can be implemented by scenes that need to let go of something
+// when they leave the stack, such as listeners on the player data
+type Unloader interface {
+	Unload()
+}
+
+// Interpolator can be implemented by scenes that smooth movement between
+// fixed steps, alpha is how far the frame being drawn is towards the next step
+type Interpolator interface {
+	Interpolate(alpha float64)
+}
+
 type SceneManager struct {
-	current            Scene
+	Clock              *gameTime.Clock
+	scenes             []Scene
+	console            ConsoleScene
 	next               Scene
+	transition         Transition
+	snapshotTaken      bool
 	transitionCount    float64
 	transitionMaxCount float64
 }
 
 type GameState struct {
 	SceneManager *SceneManager
+	Clock        *gameTime.Clock
+}
+
+func drawsBelow(scene Scene) bool {
+	if modal, ok := scene.(ModalScene); ok {
+		return modal.DrawsBelow()
+	}
+	return false
+}
+
+func updatesBelow(scene Scene) bool {
+	if modal, ok := scene.(ModalScene); ok {
+		return modal.UpdatesBelow()
+	}
+	return false
+}
+
+func capturesInput(scene Scene) bool {
+	if modal, ok := scene.(ModalScene); ok {
+		return modal.CapturesInput()
+	}
+	return true
 }
 
 func (s *SceneManager) ReadInput() {
-	if s.transitionCount <= 0 {
-		s.current.ReadInput()
+	if s.transitionCount > 0 {
+		return
+	}
+
+	// the debug keys work over every scene, the console only opens while
+	// the debug overlay is showing
+	if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		globals.Debug = !globals.Debug
+	}
+	if globals.Debug && inpututil.IsKeyJustPressed(consoleToggleKey) && s.Top() != &s.console {
+		s.Push(&s.console)
 		return
 	}
+
+	for i := len(s.scenes) - 1; i >= 0; i-- {
+		s.scenes[i].ReadInput()
+		if capturesInput(s.scenes[i]) {
+			return
+		}
+	}
 }
 
-func (s *SceneManager) Update(deltaTime float64) error {
+func (s *SceneManager) Update() error {
 	if s.transitionCount <= 0 {
-		return s.current.Update(&GameState{
+		state := &GameState{
 			SceneManager: s,
-		}, deltaTime)
+			Clock:        s.Clock,
+		}
+
+		stepped := false
+		for s.transitionCount <= 0 && s.Clock.Step() {
+			if err := s.updateStack(state, s.Clock.GetDeltaTime()); err != nil {
+				return err
+			}
+			stepped = true
+		}
+
+		// a tick without a whole step still updates with no time passing,
+		// so input read this tick and menus over a paused game are handled
+		if !stepped {
+			return s.updateStack(state, 0)
+		}
+		return nil
 	}
 
-	s.transitionCount -= 1 * deltaTime
+	s.transitionCount -= s.Clock.GetStep()
 	if s.transitionCount > 0 {
 		return nil
 	}
 
-	s.current = s.next
+	s.replaceStack(s.next)
 	s.next = nil
+	s.transition = nil
+	return nil
+}
+
+func (s *SceneManager) updateStack(state *GameState, deltaTime float64) error {
+	// scenes can push and pop while updating so walk a copy of the stack
+	stack := append([]Scene{}, s.scenes...)
+	for i := len(stack) - 1; i >= 0; i-- {
+		if err := stack[i].Update(state, deltaTime); err != nil {
+			return err
+		}
+		if !updatesBelow(stack[i]) {
+			break
+		}
+	}
 	return nil
 }
 
+func (s *SceneManager) drawStack(screen *ebiten.Image) {
+	bottom := len(s.scenes) - 1
+	for bottom > 0 && drawsBelow(s.scenes[bottom]) {
+		bottom--
+	}
+
+	alpha := s.Clock.GetAlpha()
+	for i := bottom; i < len(s.scenes); i++ {
+		if interpolator, ok := s.scenes[i].(Interpolator); ok {
+			interpolator.Interpolate(alpha)
+		}
+		s.scenes[i].Draw(screen)
+	}
+}
+
 func (s *SceneManager) Draw(screen *ebiten.Image) {
 	if s.transitionCount <= 0 {
-		s.current.Draw(screen)
+		s.drawStack(screen)
+		if globals.Debug {
+			s.drawDebugOverlay(screen)
+		}
 		return
 	}
 
-	transitionFrom.Clear()
-	s.current.Draw(transitionFrom)
+	// both scenes are drawn once when the transition starts, neither
+	// updates until it finishes so the snapshots stay accurate
+	if !s.snapshotTaken {
+		transitionFrom.Clear()
+		s.drawStack(transitionFrom)
 
-	transitionTo.Clear()
-	s.next.Draw(transitionTo)
+		transitionTo.Clear()
+		s.next.Draw(transitionTo)
+		s.snapshotTaken = true
+	}
 
-	screen.DrawImage(transitionFrom, nil)
+	progress := 1 - float64(s.transitionCount)/float64(s.transitionMaxCount)
+	s.transition.Draw(screen, transitionFrom, transitionTo, basics.FloatClamp(progress, 0, 1))
+}
 
-	alpha := 1 - float64(s.transitionCount)/float64(s.transitionMaxCount)
-	op := &ebiten.DrawImageOptions{}
-	op.ColorM.Scale(1, 1, 1, alpha)
-	screen.DrawImage(transitionTo, op)
+// Push initialises a scene and places it on top of the current one
+func (s *SceneManager) Push(scene Scene) {
+	scene.Init()
+	s.scenes = append(s.scenes, scene)
 }
 
+// Pop removes the top scene, the bottom scene can only be replaced with GoTo
+func (s *SceneManager) Pop() {
+	if len(s.scenes) > 1 {
+		unload(s.scenes[len(s.scenes)-1])
+		s.scenes = s.scenes[:len(s.scenes)-1]
+	}
+}
+
+func (s *SceneManager) Top() Scene {
+	if len(s.scenes) == 0 {
+		return nil
+	}
+	return s.scenes[len(s.scenes)-1]
+}
+
+func (s *SceneManager) Pause() {
+	if s.IsPaused() {
+		return
+	}
+
+	// there is nothing to save or leave from the title screen
+	p := &PauseScene{}
+	if _, ok := s.scenes[0].(*TitleScene); ok {
+		p.options = []string{pauseOptionResume, pauseOptionSettings, pauseOptionQuit}
+	} else {
+		p.options = []string{pauseOptionResume, pauseOptionSave, pauseOptionSettings, pauseOptionTitle, pauseOptionQuit}
+	}
+	s.Push(p)
+	// game time stops for everything under the menu, not just the scenes
+	// it stops updating
+	s.Clock.Pause()
+}
+
+func (s *SceneManager) Resume() {
+	if s.IsPaused() {
+		s.Pop()
+		s.Clock.Resume()
+	}
+}
+
+func (s *SceneManager) IsPaused() bool {
+	_, ok := s.Top().(*PauseScene)
+	return ok
+}
+
+// GoTo replaces the whole stack with the given scene, crossfading to it
 func (s *SceneManager) GoTo(scene Scene, fadeTime float64) {
-	globals.GetAudioPlayer().StopAllAudio()
+	s.GoToWithTransition(scene, &CrossfadeTransition{}, fadeTime)
+}
+
+// GoToWithTransition replaces the whole stack with the given scene. Scenes
+// that implement Loader go through a loading scene first.
+func (s *SceneManager) GoToWithTransition(scene Scene, transition Transition, fadeTime float64) {
+	if loader, ok := scene.(Loader); ok {
+		l := &LoadingScene{target: loader, transition: &CrossfadeTransition{}, fadeTime: loadingFadeTime}
+		s.goTo(l, transition, fadeTime)
+		return
+	}
+
+	s.goTo(scene, transition, fadeTime)
+}
+
+func (s *SceneManager) goTo(scene Scene, transition Transition, fadeTime float64) {
+	// a scene change already in progress wins
+	if s.transitionCount > 0 {
+		return
+	}
+
+	globals.GetAudioPlayer().StopSFX()
 	scene.Init()
+	globals.GetAudioPlayer().PreloadSFX(preloadList(scene))
 
-	if s.current == nil {
-		s.current = scene
+	if len(s.scenes) == 0 || fadeTime <= 0 {
+		s.replaceStack(scene)
 	} else {
 		s.next = scene
+		s.transition = transition
+		s.snapshotTaken = false
 		s.transitionCount = fadeTime
 		s.transitionMaxCount = fadeTime
 	}
 }
+
+func preloadList(scene Scene) []string {
+	if preloader, ok := scene.(AudioPreloader); ok {
+		return preloader.PreloadAudio()
+	}
+	return []string{}
+}
+
+// unload lets a scene leaving the stack release what it holds
+func unload(scene Scene) {
+	if unloader, ok := scene.(Unloader); ok {
+		unloader.Unload()
+	}
+}
+
+// replaceStack swaps every scene out for the new one and releases the
+// sounds only the old scenes needed
+func (s *SceneManager) replaceStack(scene Scene) {
+	for _, v := range s.scenes {
+		if v != scene {
+			unload(v)
+		}
+	}
+	s.scenes = []Scene{scene}
+	// a pause menu doesn't survive the stack being replaced
+	s.Clock.Resume()
+	globals.GetAudioPlayer().ReleaseSFX(preloadList(scene))
+}
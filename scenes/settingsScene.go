@@ -0,0 +1,372 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/globals"
+	"github.com/mharv/scrapyard-charter/resources"
+	"github.com/mharv/scrapyard-charter/settings"
+	"github.com/tinne26/etxt"
+)
+
+// settingsRow is one line of the settings list. adjust is called with -1
+// or 1 for left and right, confirm when the row is selected.
+type settingsRow struct {
+	label   string
+	value   func() string
+	adjust  func(direction float64)
+	confirm func()
+}
+
+// SettingsScene edits the user settings, every change is applied and saved
+// straight away
+type SettingsScene struct {
+	rows          []settingsRow
+	rowButtons    []basics.FloatRectUI
+	selected      int
+	scroll        int
+	wheel         float64
+	up, down      bool
+	left, right   bool
+	confirm, back bool
+	mouseClick    bool
+	cursorPos     basics.Vector2f
+	rebinding     string
+	close         bool
+	txtRenderer   *etxt.Renderer
+}
+
+const (
+	settingsPanelW, settingsPanelH     = 800, 700
+	settingsPanelX, settingsPanelY     = (globals.ScreenWidth - settingsPanelW) / 2, (globals.ScreenHeight - settingsPanelH) / 2
+	settingsHeadingX, settingsHeadingY = settingsPanelX + 30, settingsPanelY + 10
+	settingsRowX, settingsRowY         = settingsPanelX + 40, settingsPanelY + 100
+	settingsRowH                       = 29
+	settingsRowTextSize                = 25
+	settingsValueX                     = settingsPanelX + settingsPanelW - 260
+	settingsFooterY                    = settingsPanelY + settingsPanelH - 40
+	settingsVolumeStep                 = 0.1
+
+	// the list scrolls once it has more rows than fit above the footer
+	settingsVisibleRows = (settingsFooterY - 10 - settingsRowY) / settingsRowH
+	settingsScrollbarX  = settingsPanelX + settingsPanelW - 30
+	settingsScrollbarW  = 6
+)
+
+func (s *SettingsScene) Init() {
+	s.selected = 0
+	s.scroll = 0
+	s.rebinding = ""
+	s.close = false
+
+	gameSettings := globals.GetSettings()
+
+	s.rows = []settingsRow{
+		{
+			label: "Fullscreen",
+			value: func() string { return onOff(gameSettings.Fullscreen) },
+			confirm: func() {
+				gameSettings.Fullscreen = !gameSettings.Fullscreen
+			},
+		},
+		{
+			label: "Window scale",
+			value: func() string { return fmt.Sprintf("< %d%% >", int(math.Round(gameSettings.WindowScale*100))) },
+			adjust: func(direction float64) {
+				gameSettings.WindowScale = basics.FloatClamp(gameSettings.WindowScale+(direction*settings.WindowScaleStep), settings.MinWindowScale, settings.MaxWindowScale)
+			},
+		},
+		{
+			label: "VSync",
+			value: func() string { return onOff(gameSettings.VSync) },
+			confirm: func() {
+				gameSettings.VSync = !gameSettings.VSync
+			},
+		},
+		{
+			label: "Music",
+			value: func() string { return volumeText(gameSettings.MusicVolume, gameSettings.MusicMuted) },
+			adjust: func(direction float64) {
+				gameSettings.MusicVolume = basics.FloatClamp(gameSettings.MusicVolume+(direction*settingsVolumeStep), 0, 1)
+			},
+			confirm: func() {
+				gameSettings.MusicMuted = !gameSettings.MusicMuted
+			},
+		},
+		{
+			label: "Sound",
+			value: func() string { return volumeText(gameSettings.SFXVolume, gameSettings.SFXMuted) },
+			adjust: func(direction float64) {
+				gameSettings.SFXVolume = basics.FloatClamp(gameSettings.SFXVolume+(direction*settingsVolumeStep), 0, 1)
+			},
+			confirm: func() {
+				gameSettings.SFXMuted = !gameSettings.SFXMuted
+			},
+		},
+		{
+			label: "Colour-blind palette",
+			value: func() string { return onOff(gameSettings.ColourBlind) },
+			confirm: func() {
+				gameSettings.ColourBlind = !gameSettings.ColourBlind
+			},
+		},
+		{
+			label: "Debug overlays",
+			value: func() string { return onOff(gameSettings.DebugOverlays) },
+			confirm: func() {
+				gameSettings.DebugOverlays = !gameSettings.DebugOverlays
+			},
+		},
+	}
+
+	for _, v := range settings.Actions {
+		action := v
+		s.rows = append(s.rows, settingsRow{
+			label: action,
+			value: func() string {
+				if s.rebinding == action {
+					return "Press a key..."
+				}
+				return "[" + gameSettings.GetKey(action).String() + "]"
+			},
+			confirm: func() {
+				s.rebinding = action
+			},
+		})
+	}
+
+	s.rows = append(s.rows,
+		settingsRow{
+			label: "Reset key bindings",
+			value: func() string { return "" },
+			confirm: func() {
+				gameSettings.ResetKeyBindings()
+			},
+		},
+		settingsRow{
+			label: "Back",
+			value: func() string { return "" },
+			confirm: func() {
+				s.close = true
+			},
+		},
+	)
+
+	s.layoutRows()
+
+	fontLib := resources.LoadFileAsFont("fonts/Rajdhani-Regular.ttf")
+
+	s.txtRenderer = etxt.NewStdRenderer()
+	glyphsCache := etxt.NewDefaultCache(10 * 1024 * 1024) // 10MB
+	s.txtRenderer.SetCacheHandler(glyphsCache.NewHandler())
+	s.txtRenderer.SetFont(fontLib.GetFont("Rajdhani Regular"))
+	s.txtRenderer.SetAlign(etxt.Top, etxt.Left)
+}
+
+func (s *SettingsScene) DrawsBelow() bool {
+	return true
+}
+
+func (s *SettingsScene) UpdatesBelow() bool {
+	return false
+}
+
+func (s *SettingsScene) CapturesInput() bool {
+	return true
+}
+
+func (s *SettingsScene) ReadInput() {
+	// while rebinding the next key pressed is taken, escape cancels
+	if s.rebinding != "" {
+		s.up, s.down, s.left, s.right, s.confirm, s.mouseClick = false, false, false, false, false, false
+		s.back = false
+		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+			s.rebinding = ""
+			return
+		}
+		for k := ebiten.Key(0); k <= ebiten.KeyMax; k++ {
+			if inpututil.IsKeyJustPressed(k) {
+				globals.GetSettings().SetKey(s.rebinding, k)
+				s.rebinding = ""
+				s.save()
+				return
+			}
+		}
+		return
+	}
+
+	x, y := ebiten.CursorPosition()
+	s.cursorPos.X = float64(x)
+	s.cursorPos.Y = float64(y)
+
+	s.up = inpututil.IsKeyJustPressed(ebiten.KeyW) || inpututil.IsKeyJustPressed(ebiten.KeyArrowUp)
+	s.down = inpututil.IsKeyJustPressed(ebiten.KeyS) || inpututil.IsKeyJustPressed(ebiten.KeyArrowDown)
+	s.left = inpututil.IsKeyJustPressed(ebiten.KeyA) || inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft)
+	s.right = inpututil.IsKeyJustPressed(ebiten.KeyD) || inpututil.IsKeyJustPressed(ebiten.KeyArrowRight)
+	s.confirm = inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace)
+	s.back = inpututil.IsKeyJustPressed(ebiten.KeyEscape)
+	s.mouseClick = inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	_, s.wheel = ebiten.Wheel()
+}
+
+func (s *SettingsScene) Update(state *GameState, deltaTime float64) error {
+	if s.back || s.close {
+		state.SceneManager.Pop()
+		return nil
+	}
+
+	if s.rebinding != "" {
+		return nil
+	}
+
+	if s.up {
+		s.selected = (s.selected + len(s.rows) - 1) % len(s.rows)
+	}
+	if s.down {
+		s.selected = (s.selected + 1) % len(s.rows)
+	}
+	if s.up || s.down {
+		s.scrollToSelected()
+	}
+	if s.wheel > 0 && s.scroll > 0 {
+		s.scroll--
+	}
+	if s.wheel < 0 && s.scroll < len(s.rows)-settingsVisibleRows {
+		s.scroll++
+	}
+	if s.wheel != 0 {
+		s.selectInView()
+	}
+	s.layoutRows()
+
+	for i, v := range s.rowButtons {
+		if s.isRowVisible(i) && v.IsHoveredOver(s.cursorPos) {
+			s.selected = i
+			if s.mouseClick {
+				s.confirm = true
+			}
+		}
+	}
+
+	row := s.rows[s.selected]
+	if row.adjust != nil && (s.left || s.right) {
+		if s.left {
+			row.adjust(-1)
+		} else {
+			row.adjust(1)
+		}
+		s.save()
+	}
+
+	if row.confirm != nil && s.confirm {
+		row.confirm()
+		s.save()
+	}
+
+	return nil
+}
+
+// layoutRows places the rows in view for the current scroll, the ones
+// scrolled out of view keep their place above or below the panel
+func (s *SettingsScene) layoutRows() {
+	s.rowButtons = []basics.FloatRectUI{}
+	for i, v := range s.rows {
+		s.rowButtons = append(s.rowButtons, basics.FloatRectUI{
+			Name:   v.label,
+			X:      settingsRowX,
+			Y:      float64(settingsRowY + (settingsRowH * (i - s.scroll))),
+			Width:  settingsPanelW - 80,
+			Height: settingsRowH,
+		})
+	}
+}
+
+func (s *SettingsScene) isRowVisible(i int) bool {
+	return i >= s.scroll && i < s.scroll+settingsVisibleRows
+}
+
+func (s *SettingsScene) scrollToSelected() {
+	if s.selected < s.scroll {
+		s.scroll = s.selected
+	}
+	if s.selected >= s.scroll+settingsVisibleRows {
+		s.scroll = s.selected - settingsVisibleRows + 1
+	}
+}
+
+// selectInView moves the selection onto the nearest row still in view
+// after scrolling, so confirm and adjust never act on a hidden row
+func (s *SettingsScene) selectInView() {
+	if s.selected < s.scroll {
+		s.selected = s.scroll
+	}
+	if s.selected >= s.scroll+settingsVisibleRows {
+		s.selected = s.scroll + settingsVisibleRows - 1
+	}
+}
+
+func (s *SettingsScene) save() {
+	globals.ApplySettings()
+	if err := globals.GetSettings().Save(); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func onOff(value bool) string {
+	if value {
+		return "On"
+	}
+	return "Off"
+}
+
+func volumeText(volume float64, muted bool) string {
+	if muted {
+		return "Muted"
+	}
+	return fmt.Sprintf("< %d%% >", int(math.Round(volume*100)))
+}
+
+func (s *SettingsScene) Draw(screen *ebiten.Image) {
+	ebitenutil.DrawRect(screen, 0, 0, globals.ScreenWidth, globals.ScreenHeight, color.RGBA{0, 0, 0, 160})
+	ebitenutil.DrawRect(screen, settingsPanelX, settingsPanelY, settingsPanelW, settingsPanelH, color.RGBA{67, 52, 85, 255})
+	ebitenutil.DrawRect(screen, settingsPanelX+2, settingsPanelY+2, settingsPanelW-4, settingsPanelH-4, color.RGBA{154, 154, 151, 255})
+
+	s.txtRenderer.SetTarget(screen)
+	s.txtRenderer.SetSizePx(70)
+	s.txtRenderer.SetColor(color.RGBA{110, 105, 98, 255})
+	s.txtRenderer.Draw("SETTINGS", settingsHeadingX, settingsHeadingY)
+
+	s.txtRenderer.SetSizePx(settingsRowTextSize)
+	for i, v := range s.rows {
+		if !s.isRowVisible(i) {
+			continue
+		}
+		y := int(s.rowButtons[i].Y)
+		if i == s.selected {
+			ebitenutil.DrawRect(screen, s.rowButtons[i].X, s.rowButtons[i].Y, s.rowButtons[i].Width, s.rowButtons[i].Height, color.RGBA{111, 103, 118, 255})
+			s.txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+		} else {
+			s.txtRenderer.SetColor(color.RGBA{67, 52, 85, 255})
+		}
+		s.txtRenderer.Draw(v.label, settingsRowX+10, y)
+		s.txtRenderer.Draw(v.value(), settingsValueX, y)
+	}
+
+	if len(s.rows) > settingsVisibleRows {
+		trackH := float64(settingsVisibleRows * settingsRowH)
+		thumbH := trackH * settingsVisibleRows / float64(len(s.rows))
+		thumbY := settingsRowY + trackH*float64(s.scroll)/float64(len(s.rows))
+		ebitenutil.DrawRect(screen, settingsScrollbarX, settingsRowY, settingsScrollbarW, trackH, color.RGBA{110, 105, 98, 255})
+		ebitenutil.DrawRect(screen, settingsScrollbarX, thumbY, settingsScrollbarW, thumbH, color.RGBA{67, 52, 85, 255})
+	}
+
+	s.txtRenderer.SetSizePx(20)
+	s.txtRenderer.SetColor(color.RGBA{67, 52, 85, 255})
+	s.txtRenderer.Draw("[Enter] toggle or rebind   [Left/Right] adjust   [Wheel] scroll   [Esc] back", settingsRowX+10, settingsFooterY)
+}
@@ -0,0 +1,253 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/globals"
+	"github.com/mharv/scrapyard-charter/inventory"
+	"github.com/mharv/scrapyard-charter/resources"
+	"github.com/mharv/scrapyard-charter/settings"
+	"github.com/tinne26/etxt"
+)
+
+// stashRow is a stack of items or a material in one of the two columns,
+// clicking it moves one item and right clicking moves the lot
+type stashRow struct {
+	label   string
+	button  basics.FloatRectUI
+	moveOne func()
+	moveAll func()
+}
+
+// StashScene moves items and materials between what the player carries
+// and the stash kept at the home base
+type StashScene struct {
+	rows           []stashRow
+	cursorPos      basics.Vector2f
+	leftClick      bool
+	rightClick     bool
+	storeAll       bool
+	toWorkbench    bool
+	close          bool
+	message        string
+	messageCounter float64
+	txtRenderer    *etxt.Renderer
+}
+
+const (
+	stashPanelW, stashPanelH     = 1000, 700
+	stashPanelX, stashPanelY     = (globals.ScreenWidth - stashPanelW) / 2, (globals.ScreenHeight - stashPanelH) / 2
+	stashHeadingX, stashHeadingY = stashPanelX + 30, stashPanelY + 10
+	stashCarriedX, stashStashX   = stashPanelX + 40, stashPanelX + 520
+	stashColumnY                 = stashPanelY + 80
+	stashRowY                    = stashPanelY + 115
+	stashColumnW                 = 440
+	stashRowH                    = 22
+	stashSectionGap              = 10
+	stashFooterY                 = stashPanelY + stashPanelH - 36
+	stashMessageTime             = 2
+)
+
+func (s *StashScene) Init() {
+	s.close = false
+	s.message = ""
+	s.messageCounter = 0
+
+	fontLib := resources.LoadFileAsFont("fonts/Rajdhani-Regular.ttf")
+
+	s.txtRenderer = etxt.NewStdRenderer()
+	glyphsCache := etxt.NewDefaultCache(10 * 1024 * 1024) // 10MB
+	s.txtRenderer.SetCacheHandler(glyphsCache.NewHandler())
+	s.txtRenderer.SetFont(fontLib.GetFont("Rajdhani Regular"))
+	s.txtRenderer.SetAlign(etxt.Top, etxt.Left)
+
+	s.buildRows()
+}
+
+func (s *StashScene) DrawsBelow() bool {
+	return true
+}
+
+func (s *StashScene) UpdatesBelow() bool {
+	return false
+}
+
+func (s *StashScene) CapturesInput() bool {
+	return true
+}
+
+func (s *StashScene) ReadInput() {
+	x, y := ebiten.CursorPosition()
+	s.cursorPos.X = float64(x)
+	s.cursorPos.Y = float64(y)
+
+	s.leftClick = inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	s.rightClick = inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight)
+	s.storeAll = inpututil.IsKeyJustPressed(ebiten.KeyEnter)
+	s.toWorkbench = inpututil.IsKeyJustPressed(ebiten.KeyTab)
+	s.close = inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionStash))
+}
+
+func (s *StashScene) Update(state *GameState, deltaTime float64) error {
+	if s.close {
+		state.SceneManager.Pop()
+		return nil
+	}
+
+	if s.toWorkbench {
+		state.SceneManager.Pop()
+		state.SceneManager.Push(&WorkbenchScene{})
+		return nil
+	}
+
+	if s.messageCounter > 0 {
+		s.messageCounter -= deltaTime
+	}
+
+	if s.storeAll {
+		carried := globals.GetPlayerData().GetInventory()
+		for _, name := range carried.GetItemNames() {
+			moveAllItems(carried, globals.GetPlayerData().GetStash(), name)
+		}
+		for _, name := range globals.MaterialNamesList {
+			carried.MoveMaterial(globals.GetPlayerData().GetStash(), name)
+		}
+	}
+
+	for _, v := range s.rows {
+		if !v.button.IsHoveredOver(s.cursorPos) {
+			continue
+		}
+		if s.leftClick {
+			v.moveOne()
+		} else if s.rightClick {
+			v.moveAll()
+		}
+	}
+
+	s.buildRows()
+
+	return nil
+}
+
+// buildRows lays out both columns from what is currently carried and stashed
+func (s *StashScene) buildRows() {
+	carried := globals.GetPlayerData().GetInventory()
+	stash := globals.GetPlayerData().GetStash()
+
+	s.rows = []stashRow{}
+	s.addColumn(carried, stash, stashCarriedX, func(name string) { s.storeItem(name) }, func(name string) { moveAllItems(carried, stash, name) })
+	s.addColumn(stash, carried, stashStashX, func(name string) { s.takeItem(name) }, func(name string) {
+		for s.takeItem(name) {
+		}
+	})
+}
+
+func (s *StashScene) addColumn(from, to *inventory.Inventory, x float64, moveOne, moveAll func(name string)) {
+	y := float64(stashRowY)
+
+	for _, v := range from.GetItemNames() {
+		name := v
+		s.rows = append(s.rows, stashRow{
+			label:   fmt.Sprintf("%d x %s", from.GetItemCount(name), name),
+			button:  basics.FloatRectUI{Name: name, X: x, Y: y, Width: stashColumnW, Height: stashRowH},
+			moveOne: func() { moveOne(name) },
+			moveAll: func() { moveAll(name) },
+		})
+		y += stashRowH
+	}
+
+	y += stashSectionGap
+
+	for _, v := range globals.MaterialNamesList {
+		name := v
+		if from.GetMaterials()[name] <= 0 {
+			continue
+		}
+		move := func() { from.MoveMaterial(to, name) }
+		s.rows = append(s.rows, stashRow{
+			label:   fmt.Sprintf("%d x %s", from.GetMaterials()[name], name),
+			button:  basics.FloatRectUI{Name: name, X: x, Y: y, Width: stashColumnW, Height: stashRowH},
+			moveOne: move,
+			moveAll: move,
+		})
+		y += stashRowH
+	}
+}
+
+func (s *StashScene) storeItem(name string) {
+	if item, ok := globals.GetPlayerData().GetInventory().TakeItem(name); ok {
+		globals.GetPlayerData().GetStash().AddItem(item)
+	}
+}
+
+// takeItem moves one item from the stash to the player if they have room,
+// returning false once nothing more can be moved
+func (s *StashScene) takeItem(name string) bool {
+	item, ok := globals.GetPlayerData().GetStash().PeekItem(name)
+	if !ok {
+		return false
+	}
+	if !globals.GetPlayerData().CanCarry(item) {
+		s.message = "Too heavy to carry"
+		s.messageCounter = stashMessageTime
+		return false
+	}
+
+	globals.GetPlayerData().GetStash().TakeItem(name)
+	globals.GetPlayerData().GetInventory().AddItem(item)
+	return true
+}
+
+func moveAllItems(from, to *inventory.Inventory, name string) {
+	for {
+		item, ok := from.TakeItem(name)
+		if !ok {
+			return
+		}
+		to.AddItem(item)
+	}
+}
+
+func (s *StashScene) Draw(screen *ebiten.Image) {
+	ebitenutil.DrawRect(screen, 0, 0, globals.ScreenWidth, globals.ScreenHeight, color.RGBA{0, 0, 0, 160})
+	ebitenutil.DrawRect(screen, stashPanelX, stashPanelY, stashPanelW, stashPanelH, color.RGBA{67, 52, 85, 255})
+	ebitenutil.DrawRect(screen, stashPanelX+2, stashPanelY+2, stashPanelW-4, stashPanelH-4, color.RGBA{154, 154, 151, 255})
+
+	s.txtRenderer.SetTarget(screen)
+	s.txtRenderer.SetSizePx(60)
+	s.txtRenderer.SetColor(color.RGBA{110, 105, 98, 255})
+	s.txtRenderer.Draw("HOME BASE", stashHeadingX, stashHeadingY)
+
+	s.txtRenderer.SetSizePx(25)
+	s.txtRenderer.SetColor(color.RGBA{67, 52, 85, 255})
+	s.txtRenderer.Draw(
+		fmt.Sprintf("CARRIED  %.0f / %.0f", globals.GetPlayerData().GetInventory().GetItemWeight(), globals.GetPlayerData().GetCarryWeight()),
+		stashCarriedX, stashColumnY,
+	)
+	s.txtRenderer.Draw("STASH", stashStashX, stashColumnY)
+
+	s.txtRenderer.SetSizePx(20)
+	for _, v := range s.rows {
+		if v.button.IsHoveredOver(s.cursorPos) {
+			ebitenutil.DrawRect(screen, v.button.X, v.button.Y, v.button.Width, v.button.Height, color.RGBA{111, 103, 118, 255})
+			s.txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+		} else {
+			s.txtRenderer.SetColor(color.RGBA{67, 52, 85, 255})
+		}
+		s.txtRenderer.Draw(v.label, int(v.button.X)+10, int(v.button.Y))
+	}
+
+	s.txtRenderer.SetColor(color.RGBA{67, 52, 85, 255})
+	footer := "[Click] move one   [Right click] move all   [Enter] store everything   [Tab] workbench   [Esc] close"
+	if s.messageCounter > 0 {
+		s.txtRenderer.SetColor(color.RGBA{154, 79, 80, 255})
+		footer = s.message
+	}
+	s.txtRenderer.Draw(footer, stashCarriedX, stashFooterY)
+}
@@ -0,0 +1,166 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/globals"
+	"github.com/mharv/scrapyard-charter/resources"
+	"github.com/mharv/scrapyard-charter/settings"
+	"github.com/mharv/scrapyard-charter/stats"
+	"github.com/tinne26/etxt"
+)
+
+// StatsScene lists the players stats and breaks the selected one down into
+// where its value comes from
+type StatsScene struct {
+	rows        []basics.FloatRectUI
+	selected    int
+	cursorPos   basics.Vector2f
+	up, down    bool
+	close       bool
+	txtRenderer *etxt.Renderer
+}
+
+const (
+	statsPanelW, statsPanelH     = 1000, 700
+	statsPanelX, statsPanelY     = (globals.ScreenWidth - statsPanelW) / 2, (globals.ScreenHeight - statsPanelH) / 2
+	statsHeadingX, statsHeadingY = statsPanelX + 30, statsPanelY + 10
+	statsListX, statsBreakdownX  = statsPanelX + 40, statsPanelX + 520
+	statsRowY                    = statsPanelY + 100
+	statsListW                   = 440
+	statsValueX                  = statsListX + 320
+	statsRowH                    = 26
+	statsFooterY                 = statsPanelY + statsPanelH - 36
+)
+
+func (s *StatsScene) Init() {
+	s.selected = 0
+	s.close = false
+
+	fontLib := resources.LoadFileAsFont("fonts/Rajdhani-Regular.ttf")
+
+	s.txtRenderer = etxt.NewStdRenderer()
+	glyphsCache := etxt.NewDefaultCache(10 * 1024 * 1024) // 10MB
+	s.txtRenderer.SetCacheHandler(glyphsCache.NewHandler())
+	s.txtRenderer.SetFont(fontLib.GetFont("Rajdhani Regular"))
+	s.txtRenderer.SetAlign(etxt.Top, etxt.Left)
+
+	s.rows = []basics.FloatRectUI{}
+	for i, v := range globals.GetPlayerData().GetStats().GetNames() {
+		s.rows = append(s.rows, basics.FloatRectUI{Name: v, X: statsListX, Y: float64(statsRowY + i*statsRowH), Width: statsListW, Height: statsRowH})
+	}
+}
+
+func (s *StatsScene) DrawsBelow() bool {
+	return true
+}
+
+func (s *StatsScene) UpdatesBelow() bool {
+	return false
+}
+
+func (s *StatsScene) CapturesInput() bool {
+	return true
+}
+
+func (s *StatsScene) ReadInput() {
+	x, y := ebiten.CursorPosition()
+	s.cursorPos.X = float64(x)
+	s.cursorPos.Y = float64(y)
+
+	s.up = inpututil.IsKeyJustPressed(ebiten.KeyArrowUp)
+	s.down = inpututil.IsKeyJustPressed(ebiten.KeyArrowDown)
+	s.close = inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionStats))
+}
+
+func (s *StatsScene) Update(state *GameState, deltaTime float64) error {
+	if s.close {
+		state.SceneManager.Pop()
+		return nil
+	}
+
+	if s.up && s.selected > 0 {
+		s.selected--
+	}
+	if s.down && s.selected < len(s.rows)-1 {
+		s.selected++
+	}
+
+	for i, v := range s.rows {
+		if v.IsHoveredOver(s.cursorPos) {
+			s.selected = i
+		}
+	}
+
+	return nil
+}
+
+func (s *StatsScene) Draw(screen *ebiten.Image) {
+	ebitenutil.DrawRect(screen, 0, 0, globals.ScreenWidth, globals.ScreenHeight, color.RGBA{0, 0, 0, 160})
+	ebitenutil.DrawRect(screen, statsPanelX, statsPanelY, statsPanelW, statsPanelH, color.RGBA{67, 52, 85, 255})
+	ebitenutil.DrawRect(screen, statsPanelX+2, statsPanelY+2, statsPanelW-4, statsPanelH-4, color.RGBA{154, 154, 151, 255})
+
+	s.txtRenderer.SetTarget(screen)
+	s.txtRenderer.SetSizePx(60)
+	s.txtRenderer.SetColor(color.RGBA{110, 105, 98, 255})
+	s.txtRenderer.Draw("STATS", statsHeadingX, statsHeadingY)
+
+	playerStats := globals.GetPlayerData().GetStats()
+
+	s.txtRenderer.SetSizePx(22)
+	for i, v := range s.rows {
+		if i == s.selected {
+			ebitenutil.DrawRect(screen, v.X, v.Y, v.Width, v.Height, color.RGBA{111, 103, 118, 255})
+			s.txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+		} else {
+			s.txtRenderer.SetColor(color.RGBA{67, 52, 85, 255})
+		}
+		s.txtRenderer.Draw(v.Name, int(v.X)+10, int(v.Y))
+		s.txtRenderer.Draw(fmt.Sprintf("%.4g", playerStats.Get(v.Name)), statsValueX, int(v.Y))
+	}
+
+	if len(s.rows) > 0 {
+		s.drawBreakdown(playerStats.GetBreakdown(s.rows[s.selected].Name))
+	}
+
+	s.txtRenderer.SetColor(color.RGBA{67, 52, 85, 255})
+	s.txtRenderer.Draw("[Up/Down] select   [Esc] close", statsListX, statsFooterY)
+}
+
+// drawBreakdown lists the base value and every modifier on the stat, the
+// ones its stacking rule left out are greyed out
+func (s *StatsScene) drawBreakdown(breakdown stats.Breakdown) {
+	y := statsRowY
+
+	s.txtRenderer.SetSizePx(25)
+	s.txtRenderer.SetColor(color.RGBA{67, 52, 85, 255})
+	s.txtRenderer.Draw(fmt.Sprintf("%s  %.4g", breakdown.Stat, breakdown.Value), statsBreakdownX, y)
+	y += statsRowH + statsRowH/2
+
+	s.txtRenderer.SetSizePx(22)
+	s.txtRenderer.Draw(fmt.Sprintf("%.4g  Base", breakdown.Base), statsBreakdownX, y)
+	y += statsRowH
+
+	for _, v := range breakdown.Applied {
+		s.txtRenderer.Draw(formatStatModifier(v), statsBreakdownX, y)
+		y += statsRowH
+	}
+
+	s.txtRenderer.SetColor(color.RGBA{110, 105, 98, 255})
+	for _, v := range breakdown.Ignored {
+		s.txtRenderer.Draw(formatStatModifier(v)+"  (not stacked)", statsBreakdownX, y)
+		y += statsRowH
+	}
+}
+
+func formatStatModifier(modifier stats.Modifier) string {
+	if modifier.Kind == stats.Multiply {
+		return fmt.Sprintf("x%.4g  %s", modifier.Value, modifier.Source)
+	}
+	return fmt.Sprintf("%+.4g  %s", modifier.Value, modifier.Source)
+}
@@ -1,11 +1,13 @@
 package scenes
 
 import (
+	"fmt"
 	"image/color"
-	"os"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/mharv/scrapyard-charter/crafting"
+	"github.com/mharv/scrapyard-charter/data"
 	"github.com/mharv/scrapyard-charter/globals"
 	"github.com/mharv/scrapyard-charter/resources"
 	"github.com/tinne26/etxt"
@@ -13,7 +15,9 @@ import (
 
 type TitleScene struct {
 	owrld            bool
+	cont             bool
 	esc              bool
+	hasSave          bool
 	image            *ebiten.Image
 	txtRenderer      *etxt.Renderer
 	titleText        string
@@ -33,7 +37,9 @@ const (
 
 func (t *TitleScene) Init() {
 	t.owrld = false
+	t.cont = false
 	t.esc = false
+	t.hasSave = data.HasSave()
 
 	t.image = resources.LoadFileAsImage("images/titlescreen.png")
 
@@ -42,6 +48,9 @@ func (t *TitleScene) Init() {
 	t.titleText = "Scrapyard Magnate"
 	t.thoughtText = "I know that golden \nmagnet is out there...\nSomewhere..."
 	t.instructionsText = "Press [Enter] to play"
+	if t.hasSave {
+		t.instructionsText += "\nPress [C] to continue"
+	}
 
 	t.txtRenderer = etxt.NewStdRenderer()
 	glyphsCache := etxt.NewDefaultCache(10 * 1024 * 1024) // 10MB
@@ -58,6 +67,12 @@ func (t *TitleScene) ReadInput() {
 		t.owrld = false
 	}
 
+	if inpututil.IsKeyJustPressed(ebiten.KeyC) && t.hasSave {
+		t.cont = true
+	} else {
+		t.cont = false
+	}
+
 	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
 		t.esc = true
 	} else {
@@ -66,14 +81,24 @@ func (t *TitleScene) ReadInput() {
 }
 
 func (t *TitleScene) Update(state *GameState, deltaTime float64) error {
-	globals.GetAudioPlayer().PlayFile("audio/menu.mp3")
+	globals.GetAudioPlayer().PlayMusic("audio/menu.mp3")
 
 	if t.owrld {
 		o := &OverworldScene{}
-		state.SceneManager.GoTo(o, transitionTime)
+		state.SceneManager.GoToWithTransition(o, &FadeToBlackTransition{}, transitionTime)
+	}
+	if t.cont {
+		cb := &crafting.CraftingBench{}
+		cb.Init()
+		if err := globals.GetPlayerData().Load(cb.GetKeyItemByName); err != nil {
+			fmt.Println(err)
+		} else {
+			o := &OverworldScene{}
+			state.SceneManager.GoToWithTransition(o, &FadeToBlackTransition{}, transitionTime)
+		}
 	}
 	if t.esc {
-		os.Exit(0)
+		state.SceneManager.Pause()
 	}
 
 	return nil
@@ -0,0 +1,153 @@
+package scenes
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/globals"
+)
+
+// Transition draws the change between two scenes. from and to are snapshots
+// taken when the transition starts, progress runs from 0 to 1.
+type Transition interface {
+	Draw(screen, from, to *ebiten.Image, progress float64)
+}
+
+const (
+	irisMaskRadius = 256
+	diveZoom       = 4
+)
+
+var (
+	irisMask  = newCircleImage(irisMaskRadius)
+	irisLayer = ebiten.NewImage(globals.ScreenWidth, globals.ScreenHeight)
+	irisHole  = ebiten.NewImage(globals.ScreenWidth, globals.ScreenHeight)
+)
+
+// builds a filled white circle once so the iris only has to scale it
+func newCircleImage(radius int) *ebiten.Image {
+	size := radius * 2
+	pixels := make([]byte, size*size*4)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx := float64(x-radius) + 0.5
+			dy := float64(y-radius) + 0.5
+			if dx*dx+dy*dy <= float64(radius*radius) {
+				i := (y*size + x) * 4
+				pixels[i], pixels[i+1], pixels[i+2], pixels[i+3] = 0xff, 0xff, 0xff, 0xff
+			}
+		}
+	}
+
+	img := ebiten.NewImage(size, size)
+	img.ReplacePixels(pixels)
+	return img
+}
+
+// CrossfadeTransition fades the new scene in over the old one
+type CrossfadeTransition struct{}
+
+func (c *CrossfadeTransition) Draw(screen, from, to *ebiten.Image, progress float64) {
+	screen.DrawImage(from, nil)
+
+	op := &ebiten.DrawImageOptions{}
+	op.ColorM.Scale(1, 1, 1, progress)
+	screen.DrawImage(to, op)
+}
+
+// FadeToBlackTransition darkens the old scene then brightens the new one
+type FadeToBlackTransition struct{}
+
+func (f *FadeToBlackTransition) Draw(screen, from, to *ebiten.Image, progress float64) {
+	screen.Fill(color.Black)
+
+	op := &ebiten.DrawImageOptions{}
+	if progress < 0.5 {
+		op.ColorM.Scale(1, 1, 1, 1-(progress*2))
+		screen.DrawImage(from, op)
+	} else {
+		op.ColorM.Scale(1, 1, 1, (progress-0.5)*2)
+		screen.DrawImage(to, op)
+	}
+}
+
+// WipeTransition slides the new scene across the old one from the left
+type WipeTransition struct{}
+
+func (w *WipeTransition) Draw(screen, from, to *ebiten.Image, progress float64) {
+	screen.DrawImage(from, nil)
+
+	bounds := to.Bounds()
+	width := int(float64(bounds.Dx()) * progress)
+	if width <= 0 {
+		return
+	}
+	wiped := image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Min.X+width, bounds.Max.Y)
+	screen.DrawImage(to.SubImage(wiped).(*ebiten.Image), nil)
+}
+
+// IrisTransition closes a circle on the old scene around Center, then opens
+// it again on the new scene
+type IrisTransition struct {
+	Center basics.Vector2f
+}
+
+func (i *IrisTransition) Draw(screen, from, to *ebiten.Image, progress float64) {
+	scene := from
+	openness := 1 - (progress * 2)
+	if progress >= 0.5 {
+		scene = to
+		openness = (progress - 0.5) * 2
+	}
+
+	// the radius has to reach the furthest corner to show the whole scene
+	furthestX := math.Max(i.Center.X, globals.ScreenWidth-i.Center.X)
+	furthestY := math.Max(i.Center.Y, globals.ScreenHeight-i.Center.Y)
+	radius := math.Hypot(furthestX, furthestY) * openness
+
+	irisHole.Clear()
+	mop := &ebiten.DrawImageOptions{}
+	mop.GeoM.Translate(-irisMaskRadius, -irisMaskRadius)
+	mop.GeoM.Scale(radius/irisMaskRadius, radius/irisMaskRadius)
+	mop.GeoM.Translate(i.Center.X, i.Center.Y)
+	irisHole.DrawImage(irisMask, mop)
+
+	// keep only the parts of the scene that sit inside the hole
+	irisLayer.Clear()
+	irisLayer.DrawImage(scene, nil)
+	cop := &ebiten.DrawImageOptions{}
+	cop.CompositeMode = ebiten.CompositeModeDestinationIn
+	irisLayer.DrawImage(irisHole, cop)
+
+	screen.Fill(color.Black)
+	screen.DrawImage(irisLayer, nil)
+}
+
+// DiveTransition zooms the old scene in on Target while the new scene
+// fades in, used when casting from the overworld
+type DiveTransition struct {
+	Target basics.Vector2f
+}
+
+func (d *DiveTransition) Draw(screen, from, to *ebiten.Image, progress float64) {
+	screen.Fill(color.Black)
+
+	// ease in so the zoom speeds up as it dives
+	eased := progress * progress
+	scale := basics.FloatLerp(1, diveZoom, eased)
+	center := basics.Vec2FLerp(d.Target, basics.Vector2f{X: globals.ScreenWidth / 2, Y: globals.ScreenHeight / 2}, eased)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(-d.Target.X, -d.Target.Y)
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(center.X, center.Y)
+	op.ColorM.Scale(1, 1, 1, 1-eased)
+	screen.DrawImage(from, op)
+
+	op = &ebiten.DrawImageOptions{}
+	op.ColorM.Scale(1, 1, 1, eased)
+	screen.DrawImage(to, op)
+}
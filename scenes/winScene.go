@@ -2,24 +2,34 @@ package scenes
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/mharv/scrapyard-charter/globals"
 	"github.com/mharv/scrapyard-charter/resources"
 )
 
 type WinScene struct {
 	victory *ebiten.Image
+	menuBtn bool
 }
 
 func (w *WinScene) Init() {
-	globals.GetAudioPlayer().PlayFile("audio/victory.mp3")
+	globals.GetAudioPlayer().PlayMusic("audio/victory.mp3")
 
 	w.victory = resources.LoadFileAsImage("images/victory.png")
 }
 
 func (w *WinScene) ReadInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		w.menuBtn = true
+	} else {
+		w.menuBtn = false
+	}
 }
 
 func (w *WinScene) Update(state *GameState, deltaTime float64) error {
+	if w.menuBtn {
+		state.SceneManager.Pause()
+	}
 	return nil
 }
 
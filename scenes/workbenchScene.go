@@ -0,0 +1,171 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/catalog"
+	"github.com/mharv/scrapyard-charter/crafting"
+	"github.com/mharv/scrapyard-charter/globals"
+	"github.com/mharv/scrapyard-charter/resources"
+	"github.com/mharv/scrapyard-charter/settings"
+	"github.com/tinne26/etxt"
+)
+
+// WorkbenchScene crafts consumables at the home base out of the stash,
+// it shares a key with the stash to switch between the two
+type WorkbenchScene struct {
+	rows           []basics.FloatRectUI
+	icons          []*ebiten.Image
+	bench          crafting.CraftingBench
+	cursorPos      basics.Vector2f
+	leftClick      bool
+	toStash        bool
+	close          bool
+	message        string
+	messageCounter float64
+	txtRenderer    *etxt.Renderer
+}
+
+const (
+	workbenchPanelW, workbenchPanelH     = 1000, 700
+	workbenchPanelX, workbenchPanelY     = (globals.ScreenWidth - workbenchPanelW) / 2, (globals.ScreenHeight - workbenchPanelH) / 2
+	workbenchHeadingX, workbenchHeadingY = workbenchPanelX + 30, workbenchPanelY + 10
+	workbenchRowX, workbenchRowY         = workbenchPanelX + 40, workbenchPanelY + 100
+	workbenchRowW, workbenchRowH         = workbenchPanelW - 80, 84
+	workbenchIconSize                    = 64
+	workbenchTextX                       = workbenchRowX + workbenchIconSize + 30
+	workbenchFooterY                     = workbenchPanelY + workbenchPanelH - 36
+	workbenchMessageTime                 = 2
+)
+
+func (s *WorkbenchScene) Init() {
+	s.close = false
+	s.toStash = false
+	s.message = ""
+	s.messageCounter = 0
+
+	fontLib := resources.LoadFileAsFont("fonts/Rajdhani-Regular.ttf")
+
+	s.txtRenderer = etxt.NewStdRenderer()
+	glyphsCache := etxt.NewDefaultCache(10 * 1024 * 1024) // 10MB
+	s.txtRenderer.SetCacheHandler(glyphsCache.NewHandler())
+	s.txtRenderer.SetFont(fontLib.GetFont("Rajdhani Regular"))
+	s.txtRenderer.SetAlign(etxt.Top, etxt.Left)
+
+	s.rows = []basics.FloatRectUI{}
+	s.icons = []*ebiten.Image{}
+	for i, v := range catalog.ConsumableRecipes {
+		s.rows = append(s.rows, basics.FloatRectUI{Name: v.Name, X: workbenchRowX, Y: float64(workbenchRowY + i*workbenchRowH), Width: workbenchRowW, Height: workbenchRowH})
+		s.icons = append(s.icons, resources.LoadFileAsImage(v.IconFilepath))
+	}
+}
+
+func (s *WorkbenchScene) DrawsBelow() bool {
+	return true
+}
+
+func (s *WorkbenchScene) UpdatesBelow() bool {
+	return false
+}
+
+func (s *WorkbenchScene) CapturesInput() bool {
+	return true
+}
+
+func (s *WorkbenchScene) ReadInput() {
+	x, y := ebiten.CursorPosition()
+	s.cursorPos.X = float64(x)
+	s.cursorPos.Y = float64(y)
+
+	s.leftClick = inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	s.toStash = inpututil.IsKeyJustPressed(ebiten.KeyTab)
+	s.close = inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionStash))
+}
+
+func (s *WorkbenchScene) Update(state *GameState, deltaTime float64) error {
+	if s.close {
+		state.SceneManager.Pop()
+		return nil
+	}
+
+	if s.toStash {
+		state.SceneManager.Pop()
+		state.SceneManager.Push(&StashScene{})
+		return nil
+	}
+
+	if s.messageCounter > 0 {
+		s.messageCounter -= deltaTime
+	}
+
+	for i, v := range s.rows {
+		if s.leftClick && v.IsHoveredOver(s.cursorPos) {
+			if !s.bench.CraftConsumable(catalog.ConsumableRecipes[i]) {
+				s.message = "Not enough materials in the stash"
+				s.messageCounter = workbenchMessageTime
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *WorkbenchScene) Draw(screen *ebiten.Image) {
+	ebitenutil.DrawRect(screen, 0, 0, globals.ScreenWidth, globals.ScreenHeight, color.RGBA{0, 0, 0, 160})
+	ebitenutil.DrawRect(screen, workbenchPanelX, workbenchPanelY, workbenchPanelW, workbenchPanelH, color.RGBA{67, 52, 85, 255})
+	ebitenutil.DrawRect(screen, workbenchPanelX+2, workbenchPanelY+2, workbenchPanelW-4, workbenchPanelH-4, color.RGBA{154, 154, 151, 255})
+
+	s.txtRenderer.SetTarget(screen)
+	s.txtRenderer.SetSizePx(60)
+	s.txtRenderer.SetColor(color.RGBA{110, 105, 98, 255})
+	s.txtRenderer.Draw("WORKBENCH", workbenchHeadingX, workbenchHeadingY)
+
+	stash := globals.GetPlayerData().GetStash().GetMaterials()
+	carried := globals.GetPlayerData().GetInventory()
+
+	for i, v := range s.rows {
+		recipe := catalog.ConsumableRecipes[i]
+
+		textColour := color.RGBA{67, 52, 85, 255}
+		if v.IsHoveredOver(s.cursorPos) {
+			ebitenutil.DrawRect(screen, v.X, v.Y, v.Width, v.Height, color.RGBA{111, 103, 118, 255})
+			textColour = color.RGBA{197, 204, 184, 255}
+		}
+
+		iop := &ebiten.DrawImageOptions{}
+		iop.GeoM.Translate(v.X+10, v.Y+(workbenchRowH-workbenchIconSize)/2)
+		screen.DrawImage(s.icons[i], iop)
+
+		s.txtRenderer.SetColor(textColour)
+		s.txtRenderer.SetSizePx(25)
+		s.txtRenderer.Draw(fmt.Sprintf("%s  x%d", recipe.Name, carried.GetConsumableCount(recipe.Name)), workbenchTextX, int(v.Y)+6)
+
+		s.txtRenderer.SetSizePx(20)
+		s.txtRenderer.Draw(fmt.Sprintf("%s, %.0fs cooldown", recipe.Description, recipe.Cooldown), workbenchTextX, int(v.Y)+34)
+
+		cost := "Cost"
+		for _, name := range globals.MaterialNamesList {
+			if amount, ok := recipe.Materials[name]; ok {
+				cost += fmt.Sprintf(" %.0f %s", amount, name)
+			}
+		}
+		if !recipe.CanAfford(stash) {
+			s.txtRenderer.SetColor(color.RGBA{154, 79, 80, 255})
+		}
+		s.txtRenderer.Draw(cost, workbenchTextX, int(v.Y)+56)
+	}
+
+	s.txtRenderer.SetSizePx(20)
+	s.txtRenderer.SetColor(color.RGBA{67, 52, 85, 255})
+	footer := "[Click] craft one charge   [Tab] stash   [Esc] close"
+	if s.messageCounter > 0 {
+		s.txtRenderer.SetColor(color.RGBA{154, 79, 80, 255})
+		footer = s.message
+	}
+	s.txtRenderer.Draw(footer, workbenchRowX, workbenchFooterY)
+}
@@ -0,0 +1,191 @@
+package settings
+
+import (
+	"encoding/json"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mharv/scrapyard-charter/storage"
+)
+
+const (
+	settingsFileName = "settings.json"
+
+	MinWindowScale  = 0.5
+	MaxWindowScale  = 2
+	WindowScaleStep = 0.25
+)
+
+// Actions that can be rebound, in the order the settings scene lists them
+const (
+	ActionMoveUp        = "Move up"
+	ActionMoveLeft      = "Move left"
+	ActionMoveDown      = "Move down"
+	ActionMoveRight     = "Move right"
+	ActionRun           = "Run"
+	ActionCast          = "Cast"
+	ActionInventory     = "Inventory"
+	ActionStash         = "Stash"
+	ActionUpgrade       = "Upgrade"
+	ActionRepair        = "Repair"
+	ActionStats         = "Stats"
+	ActionElectroMagnet = "Electro magnet"
+	ActionRepulsor      = "Repulsor"
+	ActionHotbar1       = "Hotbar 1"
+	ActionHotbar2       = "Hotbar 2"
+	ActionHotbar3       = "Hotbar 3"
+)
+
+var Actions = []string{
+	ActionMoveUp,
+	ActionMoveLeft,
+	ActionMoveDown,
+	ActionMoveRight,
+	ActionRun,
+	ActionCast,
+	ActionInventory,
+	ActionStash,
+	ActionUpgrade,
+	ActionRepair,
+	ActionStats,
+	ActionElectroMagnet,
+	ActionRepulsor,
+	ActionHotbar1,
+	ActionHotbar2,
+	ActionHotbar3,
+}
+
+// HotbarActions use the consumables in catalog.ConsumableRecipes in order
+var HotbarActions = []string{ActionHotbar1, ActionHotbar2, ActionHotbar3}
+
+var defaultKeyBindings = map[string]ebiten.Key{
+	ActionMoveUp:        ebiten.KeyW,
+	ActionMoveLeft:      ebiten.KeyA,
+	ActionMoveDown:      ebiten.KeyS,
+	ActionMoveRight:     ebiten.KeyD,
+	ActionRun:           ebiten.KeySpace,
+	ActionCast:          ebiten.KeyE,
+	ActionInventory:     ebiten.KeyI,
+	ActionStash:         ebiten.KeyF,
+	ActionUpgrade:       ebiten.KeyU,
+	ActionRepair:        ebiten.KeyR,
+	ActionStats:         ebiten.KeyC,
+	ActionElectroMagnet: ebiten.KeySpace,
+	ActionRepulsor:      ebiten.KeyTab,
+	ActionHotbar1:       ebiten.Key1,
+	ActionHotbar2:       ebiten.Key2,
+	ActionHotbar3:       ebiten.Key3,
+}
+
+// ebiten can name a key but not parse one, so the names are mapped back once
+var keysByName = func() map[string]ebiten.Key {
+	keys := make(map[string]ebiten.Key)
+	for k := ebiten.Key(0); k <= ebiten.KeyMax; k++ {
+		if _, ok := keys[k.String()]; !ok && k.String() != "" {
+			keys[k.String()] = k
+		}
+	}
+	return keys
+}()
+
+// Palette holds the colours used to tell good from bad at a glance
+type Palette struct {
+	Good color.RGBA
+	Bad  color.RGBA
+}
+
+var (
+	defaultPalette = Palette{
+		Good: color.RGBA{0, 255, 0, 255},
+		Bad:  color.RGBA{255, 0, 0, 255},
+	}
+	// blue and orange stay apart for red-green colour blindness
+	colourBlindPalette = Palette{
+		Good: color.RGBA{0, 114, 178, 255},
+		Bad:  color.RGBA{230, 159, 0, 255},
+	}
+)
+
+// Settings are kept apart from the save file so they carry across games
+type Settings struct {
+	MusicVolume   float64
+	SFXVolume     float64
+	MusicMuted    bool
+	SFXMuted      bool
+	Fullscreen    bool
+	WindowScale   float64
+	VSync         bool
+	ColourBlind   bool
+	DebugOverlays bool
+	// key names rather than ebiten keys so the file stays readable
+	KeyBindings map[string]string
+}
+
+func (s *Settings) Init() {
+	s.MusicVolume = 1
+	s.SFXVolume = 1
+	s.MusicMuted = false
+	s.SFXMuted = false
+	s.Fullscreen = false
+	s.WindowScale = 1
+	s.VSync = true
+	s.ColourBlind = false
+	s.DebugOverlays = false
+	s.ResetKeyBindings()
+}
+
+func (s *Settings) ResetKeyBindings() {
+	s.KeyBindings = make(map[string]string)
+	for k, v := range defaultKeyBindings {
+		s.KeyBindings[k] = v.String()
+	}
+}
+
+// Returns the key bound to an action, falling back to the default binding
+// if the saved one can't be read
+func (s *Settings) GetKey(action string) ebiten.Key {
+	if key, ok := keysByName[s.KeyBindings[action]]; ok {
+		return key
+	}
+	return defaultKeyBindings[action]
+}
+
+func (s *Settings) SetKey(action string, key ebiten.Key) {
+	s.KeyBindings[action] = key.String()
+}
+
+func (s *Settings) GetPalette() Palette {
+	if s.ColourBlind {
+		return colourBlindPalette
+	}
+	return defaultPalette
+}
+
+// Load replaces the settings with the saved ones, if there are any. Fields
+// missing from an older settings file keep their current values.
+func (s *Settings) Load() error {
+	if !storage.Exists(settingsFileName) {
+		return nil
+	}
+
+	bs, err := storage.Read(settingsFileName)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(bs, s); err != nil {
+		return err
+	}
+
+	if s.WindowScale < MinWindowScale || s.WindowScale > MaxWindowScale {
+		s.WindowScale = 1
+	}
+	return nil
+}
+
+func (s *Settings) Save() error {
+	bs, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return storage.Write(settingsFileName, bs)
+}
@@ -0,0 +1,181 @@
+package stats
+
+import "sort"
+
+// Kind is how a modifier combines with the base value of its stat. A stat
+// is its base plus every Add modifier, times every Multiply modifier.
+type Kind int
+
+const (
+	Add Kind = iota
+	Multiply
+)
+
+// Stacking decides which modifiers of the same kind on a stat apply
+type Stacking int
+
+const (
+	// StackAll applies every modifier
+	StackAll Stacking = iota
+	// StackHighest only applies the biggest modifier of each kind, for
+	// stats where having two of something shouldn't count twice
+	StackHighest
+)
+
+// Modifier changes one stat. Source is what it is shown as coming from in
+// a breakdown, like the key item that gives it.
+type Modifier struct {
+	Stat   string
+	Kind   Kind
+	Value  float64
+	Source string
+}
+
+// Breakdown is where the value of a stat comes from, Ignored holds the
+// modifiers its stacking rule left out
+type Breakdown struct {
+	Stat    string
+	Base    float64
+	Applied []Modifier
+	Ignored []Modifier
+	Value   float64
+}
+
+type stat struct {
+	base     float64
+	stacking Stacking
+}
+
+// Registry holds the players stats and everything modifying them.
+// Modifiers are set in groups by an id, like the equipment slot they come
+// from, so setting a group again replaces what was there.
+type Registry struct {
+	stats     map[string]stat
+	statOrder []string
+	groups    map[string][]Modifier
+	groupIDs  []string
+	// buffs holds the time left on each group that runs out
+	buffs map[string]float64
+}
+
+func (r *Registry) Init() {
+	r.stats = make(map[string]stat)
+	r.statOrder = []string{}
+	r.groups = make(map[string][]Modifier)
+	r.groupIDs = []string{}
+	r.buffs = make(map[string]float64)
+}
+
+// Register adds a stat, registering it again changes its base and stacking
+func (r *Registry) Register(name string, base float64, stacking Stacking) {
+	if _, ok := r.stats[name]; !ok {
+		r.statOrder = append(r.statOrder, name)
+	}
+	r.stats[name] = stat{base: base, stacking: stacking}
+}
+
+// GetNames returns every stat in the order they were registered
+func (r *Registry) GetNames() []string {
+	return r.statOrder
+}
+
+// SetModifiers replaces the modifiers in the group with id
+func (r *Registry) SetModifiers(id string, modifiers []Modifier) {
+	if _, ok := r.groups[id]; !ok {
+		r.groupIDs = append(r.groupIDs, id)
+	}
+	r.groups[id] = modifiers
+}
+
+func (r *Registry) RemoveModifiers(id string) {
+	if _, ok := r.groups[id]; !ok {
+		return
+	}
+	delete(r.groups, id)
+	delete(r.buffs, id)
+	for i, v := range r.groupIDs {
+		if v == id {
+			r.groupIDs = append(r.groupIDs[:i], r.groupIDs[i+1:]...)
+			break
+		}
+	}
+}
+
+// AddBuff sets a group of modifiers that is removed once duration seconds
+// of Update have passed, adding a buff with the same id starts it again
+func (r *Registry) AddBuff(id string, modifiers []Modifier, duration float64) {
+	r.SetModifiers(id, modifiers)
+	r.buffs[id] = duration
+}
+
+// GetBuffTimeLeft returns how long the buff has left, 0 if it isn't active
+func (r *Registry) GetBuffTimeLeft(id string) float64 {
+	return r.buffs[id]
+}
+
+func (r *Registry) Update(deltaTime float64) {
+	for id, timeLeft := range r.buffs {
+		timeLeft -= deltaTime
+		if timeLeft <= 0 {
+			delete(r.buffs, id)
+			r.RemoveModifiers(id)
+			continue
+		}
+		r.buffs[id] = timeLeft
+	}
+}
+
+func (r *Registry) Get(name string) float64 {
+	return r.GetBreakdown(name).Value
+}
+
+// GetBreakdown works out a stat and records which modifiers went into it,
+// groups are walked in the order they were first set
+func (r *Registry) GetBreakdown(name string) Breakdown {
+	s := r.stats[name]
+	breakdown := Breakdown{Stat: name, Base: s.base, Applied: []Modifier{}, Ignored: []Modifier{}}
+
+	adds := []Modifier{}
+	multiplies := []Modifier{}
+	for _, id := range r.groupIDs {
+		for _, v := range r.groups[id] {
+			if v.Stat != name {
+				continue
+			}
+			if v.Kind == Multiply {
+				multiplies = append(multiplies, v)
+			} else {
+				adds = append(adds, v)
+			}
+		}
+	}
+
+	if s.stacking == StackHighest {
+		adds = keepHighest(adds, &breakdown)
+		multiplies = keepHighest(multiplies, &breakdown)
+	}
+
+	breakdown.Value = s.base
+	for _, v := range adds {
+		breakdown.Value += v.Value
+	}
+	for _, v := range multiplies {
+		breakdown.Value *= v.Value
+	}
+	breakdown.Applied = append(append(breakdown.Applied, adds...), multiplies...)
+
+	return breakdown
+}
+
+func keepHighest(modifiers []Modifier, breakdown *Breakdown) []Modifier {
+	if len(modifiers) < 2 {
+		return modifiers
+	}
+
+	sorted := append([]Modifier{}, modifiers...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Value > sorted[j].Value
+	})
+	breakdown.Ignored = append(breakdown.Ignored, sorted[1:]...)
+	return sorted[:1]
+}
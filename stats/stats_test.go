@@ -0,0 +1,130 @@
+package stats
+
+import "testing"
+
+func TestGetBreakdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		stacking Stacking
+		groups   [][]Modifier
+		want     float64
+		applied  int
+		ignored  int
+	}{
+		{
+			name:     "base only",
+			stacking: StackAll,
+			want:     10,
+		},
+		{
+			name:     "adds before multiplies whatever order they were set in",
+			stacking: StackAll,
+			groups: [][]Modifier{
+				{{Stat: "Speed", Kind: Multiply, Value: 2}},
+				{{Stat: "Speed", Kind: Add, Value: 5}},
+			},
+			want:    30,
+			applied: 2,
+		},
+		{
+			name:     "stack all applies every modifier",
+			stacking: StackAll,
+			groups: [][]Modifier{
+				{{Stat: "Speed", Kind: Add, Value: 5}},
+				{{Stat: "Speed", Kind: Add, Value: 3}},
+				{{Stat: "Speed", Kind: Multiply, Value: 2}, {Stat: "Speed", Kind: Multiply, Value: 3}},
+			},
+			want:    108,
+			applied: 4,
+		},
+		{
+			name:     "stack highest keeps the biggest of each kind",
+			stacking: StackHighest,
+			groups: [][]Modifier{
+				{{Stat: "Speed", Kind: Add, Value: 3}},
+				{{Stat: "Speed", Kind: Add, Value: 5}},
+				{{Stat: "Speed", Kind: Multiply, Value: 2}, {Stat: "Speed", Kind: Multiply, Value: 1.5}},
+			},
+			want:    30,
+			applied: 2,
+			ignored: 2,
+		},
+		{
+			name:     "modifiers on other stats are left out",
+			stacking: StackAll,
+			groups: [][]Modifier{
+				{{Stat: "Reach", Kind: Add, Value: 5}},
+			},
+			want: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Registry{}
+			r.Init()
+			r.Register("Speed", 10, tt.stacking)
+			r.Register("Reach", 0, StackAll)
+			for i, v := range tt.groups {
+				r.SetModifiers(string(rune('a'+i)), v)
+			}
+
+			breakdown := r.GetBreakdown("Speed")
+			if breakdown.Value != tt.want {
+				t.Errorf("value = %v, want %v", breakdown.Value, tt.want)
+			}
+			if len(breakdown.Applied) != tt.applied {
+				t.Errorf("applied %d modifiers, want %d", len(breakdown.Applied), tt.applied)
+			}
+			if len(breakdown.Ignored) != tt.ignored {
+				t.Errorf("ignored %d modifiers, want %d", len(breakdown.Ignored), tt.ignored)
+			}
+		})
+	}
+}
+
+func TestSetModifiersReplacesGroup(t *testing.T) {
+	r := Registry{}
+	r.Init()
+	r.Register("Speed", 10, StackAll)
+
+	r.SetModifiers("boots", []Modifier{{Stat: "Speed", Kind: Add, Value: 5}})
+	r.SetModifiers("boots", []Modifier{{Stat: "Speed", Kind: Add, Value: 2}})
+	if got := r.Get("Speed"); got != 12 {
+		t.Errorf("after replacing, Speed = %v, want 12", got)
+	}
+
+	r.RemoveModifiers("boots")
+	if got := r.Get("Speed"); got != 10 {
+		t.Errorf("after removing, Speed = %v, want 10", got)
+	}
+}
+
+func TestBuffExpiry(t *testing.T) {
+	r := Registry{}
+	r.Init()
+	r.Register("Speed", 10, StackAll)
+	r.AddBuff("run", []Modifier{{Stat: "Speed", Kind: Multiply, Value: 2}}, 1)
+
+	r.Update(0.5)
+	if got := r.Get("Speed"); got != 20 {
+		t.Errorf("during buff, Speed = %v, want 20", got)
+	}
+	if got := r.GetBuffTimeLeft("run"); got != 0.5 {
+		t.Errorf("time left = %v, want 0.5", got)
+	}
+
+	r.AddBuff("run", []Modifier{{Stat: "Speed", Kind: Multiply, Value: 2}}, 1)
+	r.Update(0.75)
+	if got := r.Get("Speed"); got != 20 {
+		t.Errorf("after restarting the buff, Speed = %v, want 20", got)
+	}
+
+	r.Update(0.5)
+	if got := r.Get("Speed"); got != 10 {
+		t.Errorf("after buff ran out, Speed = %v, want 10", got)
+	}
+	if got := r.GetBuffTimeLeft("run"); got != 0 {
+		t.Errorf("time left after expiry = %v, want 0", got)
+	}
+}
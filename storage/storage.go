@@ -0,0 +1,48 @@
+//go:build !js
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const (
+	folderName = "scrapyard-charter"
+)
+
+func getPath(name string) (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, folderName, name), nil
+}
+
+func Write(name string, data []byte) error {
+	path, err := getPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func Read(name string) ([]byte, error) {
+	path, err := getPath(name)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+func Exists(name string) bool {
+	path, err := getPath(name)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
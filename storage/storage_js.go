@@ -0,0 +1,47 @@
+//go:build js
+
+package storage
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+const (
+	keyPrefix = "scrapyard-charter/"
+)
+
+// The browser build keeps everything in local storage as there is no file system
+func getLocalStorage() (js.Value, error) {
+	localStorage := js.Global().Get("localStorage")
+	if localStorage.IsUndefined() || localStorage.IsNull() {
+		return js.Value{}, errors.New("local storage is not available")
+	}
+	return localStorage, nil
+}
+
+func Write(name string, data []byte) error {
+	localStorage, err := getLocalStorage()
+	if err != nil {
+		return err
+	}
+	localStorage.Call("setItem", keyPrefix+name, string(data))
+	return nil
+}
+
+func Read(name string) ([]byte, error) {
+	localStorage, err := getLocalStorage()
+	if err != nil {
+		return nil, err
+	}
+	value := localStorage.Call("getItem", keyPrefix+name)
+	if value.IsNull() {
+		return nil, errors.New(name + " does not exist")
+	}
+	return []byte(value.String()), nil
+}
+
+func Exists(name string) bool {
+	_, err := Read(name)
+	return err == nil
+}
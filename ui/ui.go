@@ -3,15 +3,17 @@ package ui
 import (
 	"fmt"
 	"image/color"
-	"sort"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/catalog"
 	"github.com/mharv/scrapyard-charter/crafting"
 	"github.com/mharv/scrapyard-charter/globals"
+	"github.com/mharv/scrapyard-charter/inventory"
 	"github.com/mharv/scrapyard-charter/resources"
+	"github.com/mharv/scrapyard-charter/settings"
 	"github.com/tinne26/etxt"
 )
 
@@ -22,11 +24,15 @@ type Ui struct {
 	headingTxt             *etxt.Renderer
 	characterOffset        int
 	itemsByCount           map[string]int
-	currentItemStoreLength int
+	itemsChanged           bool
+	itemListenerID         int
+	listenedInventory      *inventory.Inventory
 	sortedItemKeys         []string
 	cursorPos              basics.Vector2f
 	cursorClickPos         basics.Vector2f
 	mouseClick             bool
+	upgradeButton          bool
+	repairButton           bool
 	open                   bool
 	inventoryItems         []InventorySlotUi
 	craftButton            basics.FloatRectUI
@@ -43,13 +49,8 @@ type Ui struct {
 	salvageAllPressed      *ebiten.Image
 	salvageAllUnpressed    *ebiten.Image
 	craftPressedCounter    float64
-	rodEquip               EquippableSlot
-	reelEquip              EquippableSlot
-	lineEquip              EquippableSlot
-	magEquip               EquippableSlot
-	bootEquip              EquippableSlot
-	elecEquip              EquippableSlot
-	repEquip               EquippableSlot
+	// one for every registered slot, in the order they were registered
+	equipSlots []*EquippableSlot
 }
 
 const (
@@ -65,21 +66,36 @@ const (
 	matTextSize, invTextSize, hoverTextSize = 50, 25, 18
 	cbX, cbY, cbW, cbH                      = 118, 590, 119, 72
 	craftPressedDuration                    = 0.25
-	rodX, rodY                              = 233, 165
-	reelX, reelY                            = 171, 317
-	lineX, lineY                            = 381, 299
-	magX, magY                              = 350, 418
-	bootX, bootY                            = 103, 534
-	elecX, elecY                            = 29, 316
-	repX, repY                              = 69, 243
+	emptySlotIconAlpha                      = 0.25
+	carryX, carryY                          = 30, 632
 	invSlotW, invSlotH                      = 62, 62
 	salvageSize                             = 36
+	salvageStripY, salvageStripH            = 672, 46
+	salvageTextSize, salvageRowH            = 18, 20
+	salvageBarW, salvageBarH                = 300, 4
+	salvageLogColW, salvageLogRows          = 430, 2
+	hoverLineH, upgradePadding              = 20, 6
+	durabilityBarH                          = 4
 )
 
 func (u *Ui) IsOpen() bool {
 	return u.open
 }
 
+func (u *Ui) Open() {
+	u.open = true
+	u.openButton = true
+	u.mouseClick = false
+}
+
+// Close stops the ui listening for inventory changes
+func (u *Ui) Close() {
+	if u.listenedInventory != nil {
+		u.listenedInventory.Unsubscribe(u.itemListenerID)
+		u.listenedInventory = nil
+	}
+}
+
 func (u *Ui) Init() {
 	u.xOffset = 50
 	u.yOffset = 50
@@ -87,7 +103,11 @@ func (u *Ui) Init() {
 	u.open = false
 
 	u.itemsByCount = make(map[string]int)
-	u.currentItemStoreLength = 0
+	u.listenedInventory = globals.GetPlayerData().GetInventory()
+	u.itemListenerID = u.listenedInventory.Subscribe(func(name string, count int) {
+		u.itemsChanged = true
+	})
+	u.itemsChanged = true
 	u.sortedItemKeys = []string{}
 	u.inventoryItems = []InventorySlotUi{}
 	u.craftButton = basics.FloatRectUI{
@@ -99,75 +119,17 @@ func (u *Ui) Init() {
 	}
 
 	// init ui (which happens on overworld scene init) clears equipped items
-
-	if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Magnet") {
-
-		u.magEquip.InitEquibbaleSlot(equX+magX, equY+magY, invSlotW, invSlotH, "Magnet")
-		u.magEquip.KeyItem, _ = globals.GetPlayerData().GetEquippedItem("Magnet")
-	} else {
-
-		u.magEquip = EquippableSlot{}
-		u.magEquip.InitEquibbaleSlot(equX+magX, equY+magY, invSlotW, invSlotH, "Magnet")
-	}
-
-	if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Rod") {
-
-		u.rodEquip.InitEquibbaleSlot(equX+rodX, equY+rodY, invSlotW, invSlotH, "Rod")
-		u.rodEquip.KeyItem, _ = globals.GetPlayerData().GetEquippedItem("Rod")
-	} else {
-
-		u.rodEquip = EquippableSlot{}
-		u.rodEquip.InitEquibbaleSlot(equX+rodX, equY+rodY, invSlotW, invSlotH, "Rod")
-	}
-
-	if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Reel") {
-
-		u.reelEquip.InitEquibbaleSlot(equX+reelX, equY+reelY, invSlotW, invSlotH, "Reel")
-		u.reelEquip.KeyItem, _ = globals.GetPlayerData().GetEquippedItem("Reel")
-	} else {
-
-		u.reelEquip = EquippableSlot{}
-		u.reelEquip.InitEquibbaleSlot(equX+reelX, equY+reelY, invSlotW, invSlotH, "Reel")
-	}
-
-	if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Line") {
-
-		u.lineEquip.InitEquibbaleSlot(equX+lineX, equY+lineY, invSlotW, invSlotH, "Line")
-		u.lineEquip.KeyItem, _ = globals.GetPlayerData().GetEquippedItem("Line")
-	} else {
-
-		u.lineEquip = EquippableSlot{}
-		u.lineEquip.InitEquibbaleSlot(equX+lineX, equY+lineY, invSlotW, invSlotH, "Line")
-	}
-
-	if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Boots") {
-
-		u.bootEquip.InitEquibbaleSlot(equX+bootX, equY+bootY, invSlotW, invSlotH, "Boots")
-		u.bootEquip.KeyItem, _ = globals.GetPlayerData().GetEquippedItem("Boots")
-	} else {
-
-		u.bootEquip = EquippableSlot{}
-		u.bootEquip.InitEquibbaleSlot(equX+bootX, equY+bootY, invSlotW, invSlotH, "Boots")
-	}
-
-	if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Electromagnet") {
-
-		u.elecEquip.InitEquibbaleSlot(equX+elecX, equY+elecY, invSlotW, invSlotH, "Electromagnet")
-		u.elecEquip.KeyItem, _ = globals.GetPlayerData().GetEquippedItem("Electromagnet")
-	} else {
-
-		u.elecEquip = EquippableSlot{}
-		u.elecEquip.InitEquibbaleSlot(equX+elecX, equY+elecY, invSlotW, invSlotH, "Electromagnet")
-	}
-
-	if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Repulsor") {
-
-		u.repEquip.InitEquibbaleSlot(equX+repX, equY+repY, invSlotW, invSlotH, "Repulsor")
-		u.repEquip.KeyItem, _ = globals.GetPlayerData().GetEquippedItem("Repulsor")
-	} else {
-
-		u.repEquip = EquippableSlot{}
-		u.repEquip.InitEquibbaleSlot(equX+repX, equY+repY, invSlotW, invSlotH, "Repulsor")
+	u.equipSlots = []*EquippableSlot{}
+	for _, v := range catalog.Slots {
+		slot := &EquippableSlot{}
+		slot.InitEquibbaleSlot(equX+v.X, equY+v.Y, invSlotW, invSlotH, v.Slot)
+		slot.DrawFrame = v.DrawFrame
+		slot.Wears = v.Wears()
+		slot.Icon = LoadImage(v.IconFilepath)
+		if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied(v.Slot) {
+			slot.KeyItem, _ = globals.GetPlayerData().GetEquippedItem(v.Slot)
+		}
+		u.equipSlots = append(u.equipSlots, slot)
 	}
 
 	u.craftingBench = &crafting.CraftingBench{}
@@ -204,16 +166,13 @@ func (u *Ui) ReadInput() {
 		u.mouseClick = true
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyI) || (u.open && ebiten.IsKeyPressed(ebiten.KeyEscape)) {
+	u.upgradeButton = inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionUpgrade))
+	u.repairButton = inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionRepair))
+
+	if inpututil.IsKeyJustPressed(globals.GetSettings().GetKey(settings.ActionInventory)) || (u.open && ebiten.IsKeyPressed(ebiten.KeyEscape)) {
 		u.openButton = !u.openButton
 		if !u.openButton {
-			globals.GetPlayerData().GetInventory().NewBootsAcquired = false
-			globals.GetPlayerData().GetInventory().NewElecAcquired = false
-			globals.GetPlayerData().GetInventory().NewLineAcquired = false
-			globals.GetPlayerData().GetInventory().NewMagnetAcquired = false
-			globals.GetPlayerData().GetInventory().NewReelAcquired = false
-			globals.GetPlayerData().GetInventory().NewRepAcquired = false
-			globals.GetPlayerData().GetInventory().NewRodAcquired = false
+			globals.GetPlayerData().GetInventory().ClearNewKeyItems()
 		}
 		u.open = !u.open
 	}
@@ -221,42 +180,20 @@ func (u *Ui) ReadInput() {
 
 func (u *Ui) Update(deltaTime float64) error {
 
-	if len(globals.GetPlayerData().GetInventory().GetItems()) != u.currentItemStoreLength {
-
-		// should I just set everything to zero insted of making a new map?
+	if u.itemsChanged {
 		u.itemsByCount = make(map[string]int)
-
-		for _, v := range globals.GetPlayerData().GetInventory().GetItems() {
-
-			if val, ok := u.itemsByCount[v.GetName()]; ok {
-				u.itemsByCount[v.GetName()] = val + 1
-			} else {
-				u.itemsByCount[v.GetName()] = 1
-			}
-
-		}
-
-		u.sortedItemKeys = []string{}
-
+		u.sortedItemKeys = globals.GetPlayerData().GetInventory().GetItemNames()
 		u.inventoryItems = []InventorySlotUi{}
 
-		// sort itemsByCount here
+		for i, k := range u.sortedItemKeys {
+			u.itemsByCount[k] = globals.GetPlayerData().GetInventory().GetItemCount(k)
 
-		for k := range u.itemsByCount {
-			u.sortedItemKeys = append(u.sortedItemKeys, k)
-		}
-		sort.Strings(u.sortedItemKeys)
-
-		i := 0
-		for _, k := range u.sortedItemKeys {
 			tempInvItem := InventorySlotUi{}
-
 			tempInvItem.InitSlot(invX+salvageOffsetX-(salvageSize*2), invY+salvageOffsetY, salvageSize, salvageSize, invItemOffsetY, salvageOffsetX, salvageOffsetY, i, u.itemsByCount[k], k)
 			u.inventoryItems = append(u.inventoryItems, tempInvItem)
-			i++
 		}
 
-		u.currentItemStoreLength = len(globals.GetPlayerData().GetInventory().GetItems())
+		u.itemsChanged = false
 	}
 
 	for _, v := range u.inventoryItems {
@@ -277,56 +214,26 @@ func (u *Ui) Update(deltaTime float64) error {
 		}
 	}
 
-	if u.rodEquip.OpenKeyItemListButton.IsClicked(u.cursorClickPos) && u.mouseClick && u.openButton {
-		fmt.Printf("%s equipment slot has been pressed\n", u.rodEquip.ItemName)
-
-		equipKeyItem("Rod", &u.rodEquip)
-		u.mouseClick = false
-	}
-
-	if u.lineEquip.OpenKeyItemListButton.IsClicked(u.cursorClickPos) && u.mouseClick && u.openButton {
-		fmt.Printf("%s equipment slot has been pressed\n", u.lineEquip.ItemName)
-
-		equipKeyItem("Line", &u.lineEquip)
-		u.mouseClick = false
-	}
-
-	if u.reelEquip.OpenKeyItemListButton.IsClicked(u.cursorClickPos) && u.mouseClick && u.openButton {
-		fmt.Printf("%s equipment slot has been pressed\n", u.reelEquip.ItemName)
-
-		equipKeyItem("Reel", &u.reelEquip)
-		u.mouseClick = false
-	}
-
-	if u.magEquip.OpenKeyItemListButton.IsClicked(u.cursorClickPos) && u.mouseClick && u.openButton {
-		fmt.Printf("%s equipment slot has been pressed\n", u.magEquip.ItemName)
-		fmt.Println(globals.GetPlayerData().GetInventory().GetKeyItemsByType("Magnet"))
-
-		// do some logic here if rightmousebutton pressed to go back one.
-
-		equipKeyItem("Magnet", &u.magEquip)
-
-		u.mouseClick = false
-	}
-
-	if u.bootEquip.OpenKeyItemListButton.IsClicked(u.cursorClickPos) && u.mouseClick && u.openButton {
-		fmt.Printf("%s equipment slot has been pressed\n", u.bootEquip.ItemName)
-
-		equipKeyItem("Boots", &u.bootEquip)
-		u.mouseClick = false
-	}
-
-	if u.elecEquip.OpenKeyItemListButton.IsClicked(u.cursorClickPos) && u.mouseClick && u.openButton {
-		fmt.Printf("%s equipment slot has been pressed\n", u.elecEquip.ItemName)
-
-		equipKeyItem("Electromagnet", &u.elecEquip)
-		u.mouseClick = false
+	for _, v := range u.equipSlots {
+		if v.OpenKeyItemListButton.IsClicked(u.cursorClickPos) && u.mouseClick && u.openButton {
+			equipKeyItem(v)
+			u.mouseClick = false
+		}
 	}
 
-	if u.repEquip.OpenKeyItemListButton.IsClicked(u.cursorClickPos) && u.mouseClick && u.openButton {
-		fmt.Printf("%s equipment slot has been pressed\n", u.repEquip.ItemName)
-		equipKeyItem("Repulsor", &u.repEquip)
-		u.mouseClick = false
+	// upgrading and repairing are done at the base, on whichever slot is hovered
+	if (u.upgradeButton || u.repairButton) && u.openButton && globals.GetPlayerData().CheckIfInCraftZone() {
+		for _, v := range u.equipSlots {
+			if !v.OpenKeyItemListButton.IsHoveredOver(u.cursorPos) || !globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied(v.Slot) {
+				continue
+			}
+			if u.upgradeButton && u.craftingBench.UpgradeKeyItem(v.KeyItem) {
+				v.KeyItem, _ = globals.GetPlayerData().GetEquippedItem(v.Slot)
+			}
+			if u.repairButton && u.craftingBench.RepairKeyItem(v.KeyItem) {
+				v.KeyItem, _ = globals.GetPlayerData().GetEquippedItem(v.Slot)
+			}
+		}
 	}
 
 	if u.craftButton.IsClicked(u.cursorClickPos) && globals.GetPlayerData().CheckIfInCraftZone() && u.mouseClick && u.openButton {
@@ -394,10 +301,26 @@ func (u *Ui) Draw(screen *ebiten.Image) {
 			u.txtRenderer.Draw(fmt.Sprintf("%d x %s", v.ItemCount, v.ItemName), invX+invItemListOffsetX, invY+invItemListOffsetY+(invItemOffsetY*i))
 		}
 
+		// salvaging frees up carry weight, materials weigh nothing
+		carryText := fmt.Sprintf("Carrying %.0f / %.0f", globals.GetPlayerData().GetInventory().GetItemWeight(), globals.GetPlayerData().GetCarryWeight())
+		if globals.GetPlayerData().IsBagFull() {
+			carryText += "  BAG FULL"
+			u.txtRenderer.SetColor(color.RGBA{154, 79, 80, 255})
+		}
+		u.txtRenderer.Draw(carryText, invX+carryX, invY+carryY)
+		u.txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+
 		u.headingTxt.SetTarget(screen)
 		u.headingTxt.Draw("INVENTORY", invX+headingOffsetX, invY+headingOffsetY)
 		u.headingTxt.Draw("EQUIPMENT", equX+headingOffsetX, equY+headingOffsetY)
-		u.headingTxt.Draw("MATERIALS", matX+headingOffsetX, matY+headingOffsetY)
+		// at the base the stash is shown instead, as that is what crafting uses
+		materials := globals.GetPlayerData().GetInventory().GetMaterials()
+		if globals.GetPlayerData().CheckIfInCraftZone() {
+			materials = globals.GetPlayerData().GetStash().GetMaterials()
+			u.headingTxt.Draw("STASH", matX+headingOffsetX, matY+headingOffsetY)
+		} else {
+			u.headingTxt.Draw("MATERIALS", matX+headingOffsetX, matY+headingOffsetY)
+		}
 
 		// debuggin key items
 		// for _, v := range globals.GetPlayerData().GetInventory().GetKeyItems() {
@@ -408,7 +331,7 @@ func (u *Ui) Draw(screen *ebiten.Image) {
 		for i, v := range globals.MaterialNamesList {
 			tempVal := 0
 
-			if val, ok := globals.GetPlayerData().GetInventory().GetMaterials()[v]; ok {
+			if val, ok := materials[v]; ok {
 				tempVal = val
 			}
 
@@ -440,180 +363,240 @@ func (u *Ui) Draw(screen *ebiten.Image) {
 			)
 		}
 
-		// draws key item image
-
-		if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Rod") {
-
-			KeyItemImage := &ebiten.DrawImageOptions{}
-			KeyItemImage.GeoM.Translate(u.rodEquip.X, u.rodEquip.Y)
-			screen.DrawImage(u.rodEquip.KeyItem.GetKeyItemImage(), KeyItemImage)
+		// slots added after the panel art was drawn need their frame drawn here
+		for _, v := range u.equipSlots {
+			if v.DrawFrame {
+				drawSlotFrame(screen, v)
+			}
 		}
 
-		if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Reel") {
+		// draws key item image, or a faded icon of what goes there
 
+		for _, v := range u.equipSlots {
 			KeyItemImage := &ebiten.DrawImageOptions{}
-			KeyItemImage.GeoM.Translate(u.reelEquip.X, u.reelEquip.Y)
-			screen.DrawImage(u.reelEquip.KeyItem.GetKeyItemImage(), KeyItemImage)
-		}
-		if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Line") {
-
-			KeyItemImage := &ebiten.DrawImageOptions{}
-			KeyItemImage.GeoM.Translate(u.lineEquip.X, u.lineEquip.Y)
-			screen.DrawImage(u.lineEquip.KeyItem.GetKeyItemImage(), KeyItemImage)
+			KeyItemImage.GeoM.Translate(v.X, v.Y)
+			if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied(v.Slot) {
+				screen.DrawImage(v.KeyItem.GetKeyItemImage(), KeyItemImage)
+				if v.Wears {
+					drawDurability(screen, v)
+				}
+			} else {
+				KeyItemImage.ColorM.Scale(1, 1, 1, emptySlotIconAlpha)
+				screen.DrawImage(v.Icon, KeyItemImage)
+			}
 		}
-		if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Magnet") {
 
-			KeyItemImage := &ebiten.DrawImageOptions{}
-			KeyItemImage.GeoM.Translate(u.magEquip.X, u.magEquip.Y)
-			screen.DrawImage(u.magEquip.KeyItem.GetKeyItemImage(), KeyItemImage)
-		}
+		// Draws the new key item indicators
 
-		if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Electromagnet") {
+		for _, v := range u.equipSlots {
+			if globals.GetPlayerData().GetInventory().HasNewKeyItem(v.Slot) {
 
-			KeyItemImage := &ebiten.DrawImageOptions{}
-			KeyItemImage.GeoM.Translate(u.elecEquip.X, u.elecEquip.Y)
-			screen.DrawImage(u.elecEquip.KeyItem.GetKeyItemImage(), KeyItemImage)
+				indicatorDrawColor := color.RGBA{255, 100, 0, 255}
+				ebitenutil.DrawRect(screen, v.X, v.Y, 8, 8, indicatorDrawColor)
+			}
 		}
 
-		if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Repulsor") {
+		u.drawSalvage(screen)
 
-			KeyItemImage := &ebiten.DrawImageOptions{}
-			KeyItemImage.GeoM.Translate(u.repEquip.X, u.repEquip.Y)
-			screen.DrawImage(u.repEquip.KeyItem.GetKeyItemImage(), KeyItemImage)
-		}
+		// draws the Hover info for key items
 
-		if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied("Boots") {
+		if u.openButton {
 
-			KeyItemImage := &ebiten.DrawImageOptions{}
-			KeyItemImage.GeoM.Translate(u.bootEquip.X, u.bootEquip.Y)
-			screen.DrawImage(u.bootEquip.KeyItem.GetKeyItemImage(), KeyItemImage)
+			for _, v := range u.equipSlots {
+				if v.OpenKeyItemListButton.IsHoveredOver(u.cursorPos) {
+
+					u.txtRenderer.SetSizePx(invTextSize)
+					drawHover(screen, v, u.cursorPos, u.txtRenderer, u)
+				}
+			}
 		}
 
-		// Draws the new key item indicators
+	}
 
-		if globals.GetPlayerData().GetInventory().NewMagnetAcquired {
+}
 
-			indicatorDrawColor := color.RGBA{255, 100, 0, 255}
-			// should show u.magEquip.KeyItem.Image
-			ebitenutil.DrawRect(screen, u.magEquip.X, u.magEquip.Y, 8, 8, indicatorDrawColor)
-		}
-		if globals.GetPlayerData().GetInventory().NewRodAcquired {
+func drawHover(screen *ebiten.Image, slot *EquippableSlot, cursorPosition basics.Vector2f, txtRenderer *etxt.Renderer, u *Ui) {
+	keyItemType := slot.Slot
+	if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied(keyItemType) {
 
-			indicatorDrawColor := color.RGBA{255, 100, 0, 255}
-			ebitenutil.DrawRect(screen, u.rodEquip.X, u.rodEquip.Y, 8, 8, indicatorDrawColor)
-		}
-		if globals.GetPlayerData().GetInventory().NewReelAcquired {
+		// buttonDrawColor := color.RGBA{12, 159, 7, 255}
+		// ebitenutil.DrawRect(screen, cursorPosition.X, cursorPosition.Y-110, 100, 100, buttonDrawColor)
 
-			indicatorDrawColor := color.RGBA{255, 100, 0, 255}
-			ebitenutil.DrawRect(screen, u.reelEquip.X, u.reelEquip.Y, 8, 8, indicatorDrawColor)
-		}
-		if globals.GetPlayerData().GetInventory().NewLineAcquired {
+		tooltip := &ebiten.DrawImageOptions{}
+		tooltip.GeoM.Translate(cursorPosition.X, cursorPosition.Y-120)
+		screen.DrawImage(u.tooltipSprite, tooltip)
 
-			indicatorDrawColor := color.RGBA{255, 100, 0, 255}
-			ebitenutil.DrawRect(screen, u.lineEquip.X, u.lineEquip.Y, 8, 8, indicatorDrawColor)
-		}
-		if globals.GetPlayerData().GetInventory().NewElecAcquired {
+		// change color here
+		// txtRenderer.SetColor(color.RGBA{0, 0, 0, 255})
 
-			indicatorDrawColor := color.RGBA{255, 100, 0, 255}
-			ebitenutil.DrawRect(screen, u.elecEquip.X, u.elecEquip.Y, 8, 8, indicatorDrawColor)
+		// draw u.magEquip key item name and modifier here
+		txtRenderer.Draw(fmt.Sprintf("%s", slot.KeyItem.GetKeyItemName()), int(cursorPosition.X+25), int(cursorPosition.Y-110))
+		if len(globals.GetPlayerData().GetInventory().GetKeyItemsByType(keyItemType)) > 0 {
+			count := fmt.Sprintf(
+				"%d/%d  Lv %d",
+				globals.GetPlayerData().GetIndexOfEquippedKeyItem(slot.KeyItem)+1,
+				len(globals.GetPlayerData().GetInventory().GetKeyItemsByType(keyItemType)),
+				slot.KeyItem.GetLevel(),
+			)
+			if slot.Wears {
+				count += fmt.Sprintf("  Dur %.0f", slot.KeyItem.GetDurability())
+			}
+			txtRenderer.Draw(count, int(cursorPosition.X+25), int(cursorPosition.Y-84))
+			txtRenderer.SetSizePx(hoverTextSize)
+			for i, v := range slot.KeyItem.GetKeyItemModifiers() {
+				txtRenderer.Draw(
+					fmt.Sprintf("%s +%.4g", v.ModifierName, v.ModifierValue),
+					int(cursorPosition.X+6),
+					int(cursorPosition.Y-56)+(hoverLineH*i),
+				)
+			}
+			y := drawUpgradeCost(screen, slot.KeyItem, cursorPosition, txtRenderer)
+			drawRepairCost(screen, slot.KeyItem, basics.Vector2f{X: cursorPosition.X, Y: y}, txtRenderer)
 		}
-		if globals.GetPlayerData().GetInventory().NewRepAcquired {
+	}
+}
 
-			indicatorDrawColor := color.RGBA{255, 100, 0, 255}
-			ebitenutil.DrawRect(screen, u.repEquip.X, u.repEquip.Y, 8, 8, indicatorDrawColor)
-		}
-		if globals.GetPlayerData().GetInventory().NewBootsAcquired {
+// drawUpgradeCost shows what the next level of a key item costs under its
+// tooltip, in red while the stash can't cover it. It returns where the next
+// line under it goes.
+func drawUpgradeCost(screen *ebiten.Image, keyItem inventory.KeyItem, cursorPosition basics.Vector2f, txtRenderer *etxt.Renderer) float64 {
+	if !keyItem.CanUpgrade() && keyItem.GetLevel() < catalog.MaxKeyItemLevel {
+		return cursorPosition.Y
+	}
 
-			indicatorDrawColor := color.RGBA{255, 100, 0, 255}
-			ebitenutil.DrawRect(screen, u.bootEquip.X, u.bootEquip.Y, 8, 8, indicatorDrawColor)
+	text := "Max level"
+	textColor := color.RGBA{197, 204, 184, 255}
+	if keyItem.CanUpgrade() {
+		cost := keyItem.GetUpgradeCost()
+		text = formatCost(fmt.Sprintf("[%s] Lv %d:", globals.GetSettings().GetKey(settings.ActionUpgrade).String(), keyItem.GetLevel()+1), cost)
+		if !canPayAtBase(cost) {
+			textColor = color.RGBA{154, 79, 80, 255}
 		}
+	}
 
-		// draws the Hover info for key items
-
-		if u.openButton {
-
-			if u.reelEquip.OpenKeyItemListButton.IsHoveredOver(u.cursorPos) {
-
-				u.txtRenderer.SetSizePx(invTextSize)
-				drawHover("Reel", screen, &u.reelEquip, u.cursorPos, u.txtRenderer, u)
+	return drawCostBox(screen, text, textColor, cursorPosition, txtRenderer)
+}
 
-			}
-			if u.rodEquip.OpenKeyItemListButton.IsHoveredOver(u.cursorPos) {
+// drawRepairCost shows what repairing a worn key item costs
+func drawRepairCost(screen *ebiten.Image, keyItem inventory.KeyItem, position basics.Vector2f, txtRenderer *etxt.Renderer) {
+	if !keyItem.NeedsRepair() {
+		return
+	}
 
-				u.txtRenderer.SetSizePx(invTextSize)
-				drawHover("Rod", screen, &u.rodEquip, u.cursorPos, u.txtRenderer, u)
-			}
-			if u.lineEquip.OpenKeyItemListButton.IsHoveredOver(u.cursorPos) {
+	cost := keyItem.GetRepairCost()
+	textColor := color.RGBA{197, 204, 184, 255}
+	if !canPayAtBase(cost) {
+		textColor = color.RGBA{154, 79, 80, 255}
+	}
+	drawCostBox(screen, formatCost(fmt.Sprintf("[%s] Repair:", globals.GetSettings().GetKey(settings.ActionRepair).String()), cost), textColor, position, txtRenderer)
+}
 
-				u.txtRenderer.SetSizePx(invTextSize)
-				drawHover("Line", screen, &u.lineEquip, u.cursorPos, u.txtRenderer, u)
-			}
-			if u.magEquip.OpenKeyItemListButton.IsHoveredOver(u.cursorPos) {
+// formatCost lists the cost in the order of the materials panel
+func formatCost(text string, cost map[string]float64) string {
+	for _, v := range globals.MaterialNamesList {
+		if amount, ok := cost[v]; ok && amount > 0 {
+			text += fmt.Sprintf(" %.0f %s", amount, v)
+		}
+	}
+	if !globals.GetPlayerData().CheckIfInCraftZone() {
+		text += " at base"
+	}
+	return text
+}
 
-				u.txtRenderer.SetSizePx(invTextSize)
-				drawHover("Magnet", screen, &u.magEquip, u.cursorPos, u.txtRenderer, u)
+func canPayAtBase(cost map[string]float64) bool {
+	return globals.GetPlayerData().CheckIfInCraftZone() && catalog.CanAfford(cost, globals.GetPlayerData().GetStash().GetMaterials())
+}
 
-			}
-			if u.bootEquip.OpenKeyItemListButton.IsHoveredOver(u.cursorPos) {
+// drawCostBox draws a line of text on a dark box under position and returns
+// where the next one goes
+func drawCostBox(screen *ebiten.Image, text string, textColor color.RGBA, cursorPosition basics.Vector2f, txtRenderer *etxt.Renderer) float64 {
+	ebitenutil.DrawRect(
+		screen,
+		cursorPosition.X,
+		cursorPosition.Y+upgradePadding,
+		float64(txtRenderer.SelectionRect(text).WidthCeil()+(upgradePadding*2)),
+		hoverLineH+upgradePadding,
+		color.RGBA{40, 38, 36, 255},
+	)
+	txtRenderer.SetColor(textColor)
+	txtRenderer.Draw(text, int(cursorPosition.X)+upgradePadding, int(cursorPosition.Y)+upgradePadding+2)
+	txtRenderer.SetColor(color.RGBA{197, 204, 184, 255})
+	return cursorPosition.Y + hoverLineH + upgradePadding
+}
 
-				u.txtRenderer.SetSizePx(invTextSize)
-				drawHover("Boots", screen, &u.bootEquip, u.cursorPos, u.txtRenderer, u)
-			}
-			if u.elecEquip.OpenKeyItemListButton.IsHoveredOver(u.cursorPos) {
+// drawSalvage shows the salvage queue and what the last few salvaged items
+// gave in a strip under the panels
+func (u *Ui) drawSalvage(screen *ebiten.Image) {
+	inv := globals.GetPlayerData().GetInventory()
 
-				u.txtRenderer.SetSizePx(invTextSize)
-				drawHover("Electromagnet", screen, &u.elecEquip, u.cursorPos, u.txtRenderer, u)
-			}
-			if u.repEquip.OpenKeyItemListButton.IsHoveredOver(u.cursorPos) {
+	ebitenutil.DrawRect(screen, invX, invY+salvageStripY, globals.ScreenWidth-(invX*2), salvageStripH, color.RGBA{40, 38, 36, 255})
 
-				u.txtRenderer.SetSizePx(invTextSize)
-				drawHover("Repulsor", screen, &u.repEquip, u.cursorPos, u.txtRenderer, u)
-			}
+	u.txtRenderer.SetSizePx(salvageTextSize)
 
+	queue := inv.GetSalvageQueue()
+	if len(queue) > 0 {
+		status := fmt.Sprintf("Salvaging %s", queue[0].GetName())
+		if len(queue) > 1 {
+			status += fmt.Sprintf("  +%d queued", len(queue)-1)
 		}
-
+		if globals.GetPlayerData().CheckIfInCraftZone() {
+			status += "  (workbench)"
+		}
+		u.txtRenderer.Draw(status, invX+headingOffsetX, invY+salvageStripY+2)
+		ebitenutil.DrawRect(screen, invX+headingOffsetX, invY+salvageStripY+salvageRowH+8, salvageBarW, salvageBarH, color.RGBA{111, 103, 118, 255})
+		ebitenutil.DrawRect(screen, invX+headingOffsetX, invY+salvageStripY+salvageRowH+8, salvageBarW*inv.GetSalvageProgress(), salvageBarH, color.RGBA{197, 204, 184, 255})
+	} else {
+		u.txtRenderer.Draw(fmt.Sprintf("Salvage yield %.0f%%", globals.GetPlayerData().GetSalvageTool().Yield*100), invX+headingOffsetX, invY+salvageStripY+2)
 	}
 
+	for i, v := range inv.GetSalvageLog() {
+		if i >= salvageLogRows*2 {
+			break
+		}
+		x := equX + (i/salvageLogRows)*salvageLogColW
+		y := invY + salvageStripY + 2 + (i%salvageLogRows)*salvageRowH
+		u.txtRenderer.Draw(formatSalvageResult(v), x, y)
+	}
 }
 
-func drawHover(keyItemType string, screen *ebiten.Image, slot *EquippableSlot, cursorPosition basics.Vector2f, txtRenderer *etxt.Renderer, u *Ui) {
-	if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied(keyItemType) {
-
-		// buttonDrawColor := color.RGBA{12, 159, 7, 255}
-		// ebitenutil.DrawRect(screen, cursorPosition.X, cursorPosition.Y-110, 100, 100, buttonDrawColor)
+// formatSalvageResult lists materials in the order of the materials panel,
+// byproducts are marked with a plus
+func formatSalvageResult(result inventory.SalvageResult) string {
+	text := result.Name + ":"
+	for _, v := range globals.MaterialNamesList {
+		if amount := result.Materials[v]; amount > 0 {
+			text += fmt.Sprintf(" %d %s", amount, v)
+		}
+	}
+	for _, v := range globals.MaterialNamesList {
+		if amount := result.Byproducts[v]; amount > 0 {
+			text += fmt.Sprintf(" +%d %s", amount, v)
+		}
+	}
+	return text
+}
 
-		tooltip := &ebiten.DrawImageOptions{}
-		tooltip.GeoM.Translate(cursorPosition.X, cursorPosition.Y-120)
-		screen.DrawImage(u.tooltipSprite, tooltip)
+// drawDurability draws a bar along the bottom of the slot, red once the key
+// item is worn enough to lose some of its modifiers
+func drawDurability(screen *ebiten.Image, slot *EquippableSlot) {
+	barColor := color.RGBA{197, 204, 184, 255}
+	if slot.KeyItem.GetDurability() < catalog.WornDurability {
+		barColor = color.RGBA{154, 79, 80, 255}
+	}
 
-		// change color here
-		// txtRenderer.SetColor(color.RGBA{0, 0, 0, 255})
+	y := slot.Y + slot.Height - durabilityBarH
+	ebitenutil.DrawRect(screen, slot.X, y, slot.Width, durabilityBarH, color.RGBA{40, 38, 36, 255})
+	ebitenutil.DrawRect(screen, slot.X, y, slot.Width*slot.KeyItem.GetDurability()/catalog.MaxDurability, durabilityBarH, barColor)
+}
 
-		// draw u.magEquip key item name and modifier here
-		txtRenderer.Draw(fmt.Sprintf("%s", slot.KeyItem.GetKeyItemName()), int(cursorPosition.X+25), int(cursorPosition.Y-110))
-		if len(globals.GetPlayerData().GetInventory().GetKeyItemsByType(keyItemType)) > 0 {
-			txtRenderer.Draw(
-				fmt.Sprintf(
-					"%d/%d",
-					globals.GetPlayerData().GetIndexOfEquippedKeyItem(slot.KeyItem)+1,
-					len(globals.GetPlayerData().GetInventory().GetKeyItemsByType(keyItemType)),
-				),
-				int(cursorPosition.X+25),
-				int(cursorPosition.Y-70),
-			)
-			txtRenderer.Draw(
-				fmt.Sprintf(
-					"%s +%0.f", slot.KeyItem.GetKeyItemModifiers().ModifierName,
-					slot.KeyItem.GetKeyItemModifiers().ModifierValue,
-				),
-				int(cursorPosition.X+6),
-				int(cursorPosition.Y-30),
-			)
-		}
-	}
+func drawSlotFrame(screen *ebiten.Image, slot *EquippableSlot) {
+	ebitenutil.DrawRect(screen, slot.X, slot.Y, slot.Width, slot.Height, color.RGBA{67, 52, 85, 255})
+	ebitenutil.DrawRect(screen, slot.X+1, slot.Y+1, slot.Width-2, slot.Height-2, color.RGBA{111, 103, 118, 255})
 }
 
-func equipKeyItem(keyItemType string, slot *EquippableSlot) {
+func equipKeyItem(slot *EquippableSlot) {
+	keyItemType := slot.Slot
 
 	if globals.GetPlayerData().CheckKeyItemTypeSlotIfOccupied(keyItemType) {
 		getIndexOfEquippedItem := globals.GetPlayerData().GetIndexOfEquippedKeyItem(slot.KeyItem)
@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/mharv/scrapyard-charter/basics"
+	"github.com/mharv/scrapyard-charter/catalog"
 	"github.com/mharv/scrapyard-charter/inventory"
 )
 
@@ -53,19 +55,22 @@ type EquippableSlot struct {
 	Width                 float64
 	Height                float64
 	OpenKeyItemListButton basics.FloatRectUI
-	ItemName              string
+	Slot                  catalog.Slot
 	KeyItem               inventory.KeyItem
+	Icon                  *ebiten.Image
+	DrawFrame             bool
+	Wears                 bool
 }
 
-func (e *EquippableSlot) InitEquibbaleSlot(invX, invY, invW, invH float64, itemName string) {
+func (e *EquippableSlot) InitEquibbaleSlot(invX, invY, invW, invH float64, slot catalog.Slot) {
 	e.X = invX
 	e.Y = invY
 	e.Width = invW
 	e.Height = invH
-	e.ItemName = itemName
+	e.Slot = slot
 
 	e.OpenKeyItemListButton = basics.FloatRectUI{
-		Name:   itemName,
+		Name:   string(slot),
 		X:      e.X,
 		Y:      e.Y,
 		Height: e.Height,